@@ -0,0 +1,82 @@
+package main
+
+import (
+	"database/sql"
+	"flag"
+	"fmt"
+	"os"
+	"otamaker-api/internal/importer"
+	"otamaker-api/internal/models"
+	"time"
+)
+
+// runImportAnime: Subcomando "import-anime".
+// Uso: api import-anime -format mal|anilist -file export.xml [-dry-run] [-driver postgres] [-dsn ...]
+// Grava no banco via importer.SQLStore (DSN por flag ou DATABASE_URL). Com -dry-run usa MemoryStore:
+// nada é gravado e só o relatório é exibido. O driver SQL precisa estar registrado no binário (import em branco).
+// dryRun: valor padrão de -dry-run (o atalho "validate-anime-import" já liga).
+func runImportAnime(args []string, dryRun bool) int {
+	fs := flag.NewFlagSet("import-anime", flag.ContinueOnError)
+	format := fs.String("format", "", "formato do arquivo: mal | anilist")
+	file := fs.String("file", "", "caminho do arquivo de exportação")
+	dry := fs.Bool("dry-run", dryRun, "valida e mostra o relatório sem gravar (MemoryStore)")
+	driver := fs.String("driver", "postgres", "driver database/sql registrado no binário")
+	dsn := fs.String("dsn", os.Getenv("DATABASE_URL"), "conexão com o banco (padrão: $DATABASE_URL)")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	var parse importer.Parser
+	switch models.ExternalProvider(*format) {
+	case models.ProviderMAL:
+		parse = importer.ParseMAL
+	case models.ProviderAniList:
+		parse = importer.ParseAniList
+	default:
+		fmt.Fprintln(os.Stderr, "formato inválido: use -format mal ou -format anilist")
+		return 2
+	}
+
+	f, err := os.Open(*file)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer f.Close()
+
+	records, err := parse(f)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	var store importer.Store
+	if *dry {
+		fmt.Println("Dry-run: validação do arquivo (nada é gravado).")
+		store = importer.NewMemoryStore()
+	} else {
+		if *dsn == "" {
+			fmt.Fprintln(os.Stderr, "informe -dsn ou DATABASE_URL (ou use -dry-run)")
+			return 2
+		}
+		db, err := sql.Open(*driver, *dsn)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		defer db.Close()
+		if err := db.Ping(); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		store = importer.NewSQLStore(db)
+	}
+
+	report := importer.Run(records, store, time.Now())
+	report.Print(os.Stdout)
+
+	if len(report.Errors) > 0 {
+		return 1
+	}
+	return 0
+}
@@ -1,8 +1,21 @@
 package main
 
-import "fmt"
+import (
+	"fmt"
+	"os"
+)
 
 func main() {
+	// Subcomandos offline (CLI).
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "import-anime":
+			os.Exit(runImportAnime(os.Args[2:], false))
+		case "validate-anime-import": // Atalho antigo = import-anime -dry-run.
+			os.Exit(runImportAnime(os.Args[2:], true))
+		}
+	}
+
 	fmt.Println("Servidor rodando em http://localhost")
 	
 }
@@ -0,0 +1,119 @@
+package constants
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Hemisphere define o hemisfério do usuário (para rótulos de exibição).
+type Hemisphere string
+
+const (
+	North Hemisphere = "north"
+	South Hemisphere = "south"
+)
+
+// Regiões (sufixo do locale: "pt-BR" -> "br") no hemisfério sul.
+var southernRegions = map[string]bool{
+	"br": true,
+	"ar": true,
+	"cl": true,
+	"uy": true,
+	"py": true,
+	"bo": true,
+	"pe": true,
+	"au": true,
+	"nz": true,
+	"za": true,
+}
+
+// Estação oposta (Sul <-> Norte), aplicada sobre a estação meteorológica do Norte.
+var oppositeSeason = map[Season]Season{
+	Winter: Summer,
+	Summer: Winter,
+	Spring: Autumn,
+	Autumn: Spring,
+}
+
+// HemisphereFromLocale: ENTRADA (Locale cru do cliente: "es-AR", "pt-PT", "en_AU").
+// Só a região decide: o idioma sozinho não diz onde o usuário está ("pt" vale para Brasil e Portugal).
+// Sem região: Norte. Use HemisphereFromCountry quando o país vier de outra fonte (perfil, geolocalização).
+func HemisphereFromLocale(input string) Hemisphere {
+	key := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(input), "-", "_"))
+	_, region, hasRegion := strings.Cut(key, "_")
+	if !hasRegion {
+		return North
+	}
+	return HemisphereFromCountry(region)
+}
+
+// HemisphereFromCountry: Código ISO 3166-1 alfa-2 ("BR", "pt"). Desconhecido: Norte.
+func HemisphereFromCountry(code string) Hemisphere {
+	if southernRegions[strings.ToLower(strings.TrimSpace(code))] {
+		return South
+	}
+	return North
+}
+
+// Hemisphere: Hemisfério padrão do idioma do banco ("pt_br" -> Sul).
+// Prefira HemisphereFromLocale quando o locale cru do cliente estiver disponível.
+func (l Language) Hemisphere() Hemisphere {
+	return HemisphereFromLocale(string(l))
+}
+
+// GetLocalSeasonByDate: Estação meteorológica local (Dez-Fev = Inverno no Norte, Verão no Sul).
+// NÃO usar para filtros: o valor canônico é sempre GetSeasonByDate (calendário de exibição).
+func GetLocalSeasonByDate(date time.Time, h Hemisphere) Season {
+	var s Season
+	switch date.Month() {
+	case time.December, time.January, time.February:
+		s = Winter
+	case time.March, time.April, time.May:
+		s = Spring
+	case time.June, time.July, time.August:
+		s = Summer
+	default:
+		s = Autumn
+	}
+	if h == South {
+		return oppositeSeason[s]
+	}
+	return s
+}
+
+// LocalSeasons: Estações locais cobertas pelos meses da temporada de exibição, em ordem.
+// Norte: a própria temporada (o nome da indústria já é o que o usuário conhece).
+// Sul: mapeado mês a mês (GetLocalSeasonByDate). Ex.: Winter (Jan-Mar) -> [Summer, Autumn], porque Março já é outono.
+func (s Season) LocalSeasons(h Hemisphere) []Season {
+	if h != South || !s.IsValid() {
+		return []Season{s}
+	}
+	first := time.Month(seasonOrder[s]*3 + 1)
+	out := make([]Season, 0, 2)
+	for m := first; m < first+3; m++ {
+		local := GetLocalSeasonByDate(time.Date(2000, m, 1, 0, 0, 0, 0, time.UTC), h)
+		if len(out) == 0 || out[len(out)-1] != local {
+			out = append(out, local)
+		}
+	}
+	return out
+}
+
+// DisplayName: Rótulo local da temporada de exibição. Ex.: Winter no Sul (pt_br) -> "Verão/Outono".
+func (s Season) DisplayName(h Hemisphere, lang Language) string {
+	locals := s.LocalSeasons(h)
+	names := make([]string, 0, len(locals))
+	for _, local := range locals {
+		names = append(names, local.Translate(lang))
+	}
+	return strings.Join(names, "/")
+}
+
+// DisplayName: Mesmo rótulo mantendo o ano da temporada canônica. Ex.: "Verão/Outono 2026".
+func (sy SeasonYear) DisplayName(h Hemisphere, lang Language) string {
+	if sy.IsZero() {
+		return ""
+	}
+	return sy.Season.DisplayName(h, lang) + " " + strconv.Itoa(sy.Year)
+}
@@ -0,0 +1,68 @@
+package constants
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHemisphereFromLocale(t *testing.T) {
+	cases := []struct {
+		input string
+		want  Hemisphere
+	}{
+		{"pt-BR", South},
+		{"es_AR", South},
+		{"en-AU", South},
+		{"pt-PT", North},
+		{"pt", North},
+		{"", North},
+		{"pt_br", South},
+	}
+	for _, tc := range cases {
+		if got := HemisphereFromLocale(tc.input); got != tc.want {
+			t.Errorf("%q = %q, want %q", tc.input, got, tc.want)
+		}
+	}
+}
+
+func TestGetLocalSeasonByDate(t *testing.T) {
+	cases := []struct {
+		month time.Month
+		h     Hemisphere
+		want  Season
+	}{
+		{time.January, North, Winter},
+		{time.January, South, Summer},
+		{time.March, North, Spring},
+		{time.March, South, Autumn},
+		{time.July, South, Winter},
+		{time.October, South, Spring},
+	}
+	for _, tc := range cases {
+		got := GetLocalSeasonByDate(time.Date(2026, tc.month, 15, 0, 0, 0, 0, time.UTC), tc.h)
+		if got != tc.want {
+			t.Errorf("%s/%s = %q, want %q", tc.month, tc.h, got, tc.want)
+		}
+	}
+}
+
+func TestSeasonDisplayName(t *testing.T) {
+	cases := []struct {
+		season SeasonYear
+		h      Hemisphere
+		lang   Language
+		want   string
+	}{
+		{SeasonYear{Winter, 2026}, North, EN_US, "Winter 2026"},
+		{SeasonYear{Winter, 2026}, South, PT_BR, "Verão/Outono 2026"},
+		{SeasonYear{Spring, 2026}, South, PT_BR, "Outono/Inverno 2026"},
+		{SeasonYear{Summer, 2025}, South, PT_BR, "Inverno/Primavera 2025"},
+		{SeasonYear{Autumn, 2025}, South, PT_BR, "Primavera/Verão 2025"},
+		{SeasonYear{}, South, PT_BR, ""},
+	}
+	for _, tc := range cases {
+		if got := tc.season.DisplayName(tc.h, tc.lang); got != tc.want {
+			t.Errorf("%v/%s = %q, want %q", tc.season, tc.h, got, tc.want)
+		}
+	}
+}
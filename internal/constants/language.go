@@ -83,11 +83,15 @@ func NormalizeLanguage(input string) Language {
 }
 
 // IsSupported verifica se o idioma é válido sem fazer fallback para o Default.
-// Útil para rejeitar idiomas não suportados.
+// Útil para rejeitar idiomas não suportados. Aceita os mesmos formatos de NormalizeLanguage (\"pt-BR\", \"pt\", \"es_mx\").
 func IsSupported(input string) bool {
-	lang := NormalizeLanguage(input)
-	// Valida se não retornou Default
-	return lang != Default || input == string(Default)
+	key := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(input)), "-", "_")
+	if supported[Language(key)] {
+		return true
+	}
+	prefix, _, _ := strings.Cut(key, "_")
+	_, ok := parents[prefix]
+	return ok
 }
 
 // ==========================================================
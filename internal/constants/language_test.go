@@ -0,0 +1,27 @@
+package constants
+
+import "testing"
+
+func TestIsSupported(t *testing.T) {
+	cases := []struct {
+		input string
+		want  bool
+		norm  Language
+	}{
+		{"pt_br", true, PT_BR},
+		{"pt-BR", true, PT_BR},
+		{"en", true, EN_US},
+		{"es_mx", true, ES_ES},
+		{" EN-us ", true, EN_US},
+		{"fr", false, Default},
+		{"", false, Default},
+	}
+	for _, tc := range cases {
+		if got := IsSupported(tc.input); got != tc.want {
+			t.Errorf("IsSupported(%q) = %v, want %v", tc.input, got, tc.want)
+		}
+		if got := NormalizeLanguage(tc.input); got != tc.norm {
+			t.Errorf("NormalizeLanguage(%q) = %q, want %q", tc.input, got, tc.norm)
+		}
+	}
+}
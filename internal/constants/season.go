@@ -66,6 +66,7 @@ func (s Season) IsValid() bool {
 }
 
 // GetSeasonByDate: O Sistema define a temporada (Lógica).
+// Calendário de exibição japonês (canônico). Para o rótulo local, ver hemisphere.go.
 func GetSeasonByDate(date time.Time) Season {
 	month := date.Month()
 	switch month {
@@ -82,6 +83,21 @@ func GetSeasonByDate(date time.Time) Season {
 	}
 }
 
+// SeasonBounds: Início (inclusivo) e fim (exclusivo) da temporada que contém a data.
+// Ex.: 2026-02-10 -> [2026-01-01, 2026-04-01) (Winter).
+func SeasonBounds(date time.Time) (start, end time.Time) {
+	firstMonth := time.Month((int(date.Month())-1)/3*3 + 1)
+	start = time.Date(date.Year(), firstMonth, 1, 0, 0, 0, 0, date.Location())
+	return start, start.AddDate(0, 3, 0)
+}
+
+// PreviousSeasonDate: Uma data qualquer dentro da temporada anterior.
+// Atravessa a virada do ano (Winter 2026 -> Autumn 2025).
+func PreviousSeasonDate(date time.Time) time.Time {
+	start, _ := SeasonBounds(date)
+	return start.AddDate(0, -3, 0)
+}
+
 // Translate: SAÍDA (Banco -> Cliente).
 // Usa a inteligência de Language.Get para fallback, default, etc.
 func (s Season) Translate(lang Language) string {
@@ -0,0 +1,197 @@
+package constants
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SeasonYear: Temporada com ano (ex: "winter-2026").
+// Resolve a ambiguidade de Season sozinha ("Winter" casava com todos os anos).
+// Formato canônico no banco: "<season>-<ano>" (slug inglês, minúsculo).
+type SeasonYear struct {
+	Season Season
+	Year   int
+}
+
+// Ordem das temporadas dentro do ano (calendário de exibição japonês).
+var seasonOrder = map[Season]int{
+	Winter: 0,
+	Spring: 1,
+	Summer: 2,
+	Autumn: 3,
+}
+
+var seasonByOrder = [4]Season{Winter, Spring, Summer, Autumn}
+
+// NewSeasonYear valida e monta o valor.
+func NewSeasonYear(s Season, year int) (SeasonYear, error) {
+	if !s.IsValid() {
+		return SeasonYear{}, fmt.Errorf("Season not found: %s", s)
+	}
+	if year < 1900 || year > 2100 {
+		return SeasonYear{}, fmt.Errorf("ano inválido: %d", year)
+	}
+	return SeasonYear{Season: s, Year: year}, nil
+}
+
+// SeasonYearOf: O Sistema define a temporada+ano de uma data (mesma lógica de GetSeasonByDate).
+func SeasonYearOf(date time.Time) SeasonYear {
+	return SeasonYear{Season: GetSeasonByDate(date), Year: date.Year()}
+}
+
+// ParseSeasonYear: ENTRADA (Cliente -> Banco), em qualquer idioma e separador.
+// Ex.: "Verão 2025", "summer-2025", "Verano 2025", "2025 summer" -> summer-2025.
+func ParseSeasonYear(input string) (SeasonYear, error) {
+	fields := strings.FieldsFunc(strings.ToLower(strings.TrimSpace(input)), func(r rune) bool {
+		return r == ' ' || r == '-' || r == '_' || r == '/'
+	})
+
+	var (
+		season  Season
+		year    int
+		hasYear bool
+	)
+	for _, f := range fields {
+		if n, err := strconv.Atoi(f); err == nil {
+			if hasYear {
+				return SeasonYear{}, fmt.Errorf("temporada inválida: %s", input)
+			}
+			year, hasYear = n, true
+			continue
+		}
+		s, err := NormalizeSeason(f)
+		if err != nil || season != "" {
+			return SeasonYear{}, fmt.Errorf("temporada inválida: %s", input)
+		}
+		season = s
+	}
+
+	if season == "" || !hasYear {
+		return SeasonYear{}, fmt.Errorf("temporada inválida: %s", input)
+	}
+	return NewSeasonYear(season, year)
+}
+
+// IsZero: Valor vazio (anime sem temporada definida).
+func (sy SeasonYear) IsZero() bool {
+	return sy.Season == "" && sy.Year == 0
+}
+
+// String: Formato canônico do banco ("summer-2025").
+func (sy SeasonYear) String() string {
+	if sy.IsZero() {
+		return ""
+	}
+	return string(sy.Season) + "-" + strconv.Itoa(sy.Year)
+}
+
+// Translate: SAÍDA (Banco -> Cliente). Ex.: pt_br -> "Verão 2025".
+func (sy SeasonYear) Translate(lang Language) string {
+	if sy.IsZero() {
+		return ""
+	}
+	return sy.Season.Translate(lang) + " " + strconv.Itoa(sy.Year)
+}
+
+// ==========================================================
+// ORDENAÇÃO E INTERVALOS
+// ==========================================================
+
+// index: Posição absoluta (ano*4 + ordem), base para comparação e iteração.
+func (sy SeasonYear) index() int {
+	return sy.Year*4 + seasonOrder[sy.Season]
+}
+
+// seasonYearFromIndex usa divisão/módulo com piso: índices negativos (ex: Prev do valor vazio) não estouram.
+func seasonYearFromIndex(i int) SeasonYear {
+	order := ((i % 4) + 4) % 4
+	return SeasonYear{Season: seasonByOrder[order], Year: (i - order) / 4}
+}
+
+// Compare: -1 se antes, 0 se igual, 1 se depois.
+func (sy SeasonYear) Compare(other SeasonYear) int {
+	a, b := sy.index(), other.index()
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func (sy SeasonYear) Before(other SeasonYear) bool { return sy.Compare(other) < 0 }
+func (sy SeasonYear) After(other SeasonYear) bool  { return sy.Compare(other) > 0 }
+
+// Next/Prev atravessam a virada do ano (Autumn 2025 -> Winter 2026).
+func (sy SeasonYear) Next() SeasonYear { return seasonYearFromIndex(sy.index() + 1) }
+func (sy SeasonYear) Prev() SeasonYear { return seasonYearFromIndex(sy.index() - 1) }
+
+// Bounds: Início (inclusivo) e fim (exclusivo) da temporada em UTC.
+func (sy SeasonYear) Bounds() (start, end time.Time) {
+	month := time.Month(seasonOrder[sy.Season]*3 + 1)
+	return SeasonBounds(time.Date(sy.Year, month, 1, 0, 0, 0, 0, time.UTC))
+}
+
+// SeasonYearRange: Todas as temporadas de 'from' até 'to' (inclusive), em ordem.
+// Intervalo invertido devolve lista vazia.
+func SeasonYearRange(from, to SeasonYear) []SeasonYear {
+	if from.After(to) {
+		return []SeasonYear{}
+	}
+	out := make([]SeasonYear, 0, to.index()-from.index()+1)
+	for i := from.index(); i <= to.index(); i++ {
+		out = append(out, seasonYearFromIndex(i))
+	}
+	return out
+}
+
+// ==========================================================
+// SERIALIZAÇÃO (JSON e Banco)
+// ==========================================================
+
+// MarshalText: JSON/form usam o formato canônico.
+func (sy SeasonYear) MarshalText() ([]byte, error) {
+	return []byte(sy.String()), nil
+}
+
+// UnmarshalText aceita qualquer formato suportado por ParseSeasonYear.
+func (sy *SeasonYear) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*sy = SeasonYear{}
+		return nil
+	}
+	parsed, err := ParseSeasonYear(string(text))
+	if err != nil {
+		return err
+	}
+	*sy = parsed
+	return nil
+}
+
+// Value: Grava como texto canônico (NULL quando vazio).
+func (sy SeasonYear) Value() (driver.Value, error) {
+	if sy.IsZero() {
+		return nil, nil
+	}
+	return sy.String(), nil
+}
+
+// Scan: Lê o texto canônico do banco.
+func (sy *SeasonYear) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*sy = SeasonYear{}
+		return nil
+	case string:
+		return sy.UnmarshalText([]byte(v))
+	case []byte:
+		return sy.UnmarshalText(v)
+	default:
+		return fmt.Errorf("SeasonYear: tipo não suportado %T", src)
+	}
+}
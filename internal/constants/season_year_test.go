@@ -0,0 +1,119 @@
+package constants
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestParseSeasonYear(t *testing.T) {
+	cases := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"summer-2025", "summer-2025", false},
+		{"Verão 2025", "summer-2025", false},
+		{"Verano 2025", "summer-2025", false},
+		{"2026 winter", "winter-2026", false},
+		{"  OUTONO_2024 ", "autumn-2024", false},
+		{"summer", "", true},
+		{"2025", "", true},
+		{"summer 2025 2026", "", true},
+		{"summer winter 2025", "", true},
+		{"summer 1800", "", true},
+	}
+	for _, tc := range cases {
+		got, err := ParseSeasonYear(tc.input)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("%q: err = %v, wantErr %v", tc.input, err, tc.wantErr)
+			continue
+		}
+		if got.String() != tc.want {
+			t.Errorf("%q = %q, want %q", tc.input, got.String(), tc.want)
+		}
+	}
+}
+
+func TestSeasonYearNavigation(t *testing.T) {
+	cases := []struct {
+		name string
+		got  SeasonYear
+		want SeasonYear
+	}{
+		{"next atravessa o ano", SeasonYear{Autumn, 2025}.Next(), SeasonYear{Winter, 2026}},
+		{"prev atravessa o ano", SeasonYear{Winter, 2026}.Prev(), SeasonYear{Autumn, 2025}},
+		{"next dentro do ano", SeasonYear{Spring, 2025}.Next(), SeasonYear{Summer, 2025}},
+		{"data de dezembro", SeasonYearOf(time.Date(2025, 12, 28, 0, 0, 0, 0, time.UTC)), SeasonYear{Autumn, 2025}},
+	}
+	for _, tc := range cases {
+		if tc.got != tc.want {
+			t.Errorf("%s = %v, want %v", tc.name, tc.got, tc.want)
+		}
+	}
+
+	if !(SeasonYear{Autumn, 2025}).Before(SeasonYear{Winter, 2026}) || (SeasonYear{Winter, 2026}).Compare(SeasonYear{Winter, 2026}) != 0 {
+		t.Errorf("Compare fora de ordem")
+	}
+}
+
+func TestSeasonYearBoundsAndRange(t *testing.T) {
+	start, end := SeasonYear{Summer, 2025}.Bounds()
+	if !start.Equal(time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)) || !end.Equal(time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Bounds = %v .. %v", start, end)
+	}
+
+	cases := []struct {
+		from, to SeasonYear
+		want     int
+	}{
+		{SeasonYear{Autumn, 2025}, SeasonYear{Spring, 2026}, 3},
+		{SeasonYear{Winter, 2025}, SeasonYear{Winter, 2025}, 1},
+		{SeasonYear{Spring, 2026}, SeasonYear{Autumn, 2025}, 0},
+	}
+	for _, tc := range cases {
+		got := SeasonYearRange(tc.from, tc.to)
+		if len(got) != tc.want {
+			t.Errorf("Range(%v, %v) = %v, want %d itens", tc.from, tc.to, got, tc.want)
+		}
+		if tc.want > 0 && (got[0] != tc.from || got[len(got)-1] != tc.to) {
+			t.Errorf("Range(%v, %v) = %v", tc.from, tc.to, got)
+		}
+	}
+}
+
+func TestSeasonYearSerialization(t *testing.T) {
+	raw, err := json.Marshal(map[string]SeasonYear{"season": {Winter, 2026}})
+	if err != nil || string(raw) != `{"season":"winter-2026"}` {
+		t.Errorf("Marshal = %s, %v", raw, err)
+	}
+
+	var in struct {
+		Season SeasonYear `json:"season"`
+	}
+	if err := json.Unmarshal([]byte(`{"season":"Inverno 2026"}`), &in); err != nil || in.Season != (SeasonYear{Winter, 2026}) {
+		t.Errorf("Unmarshal = %v, %v", in.Season, err)
+	}
+
+	cases := []struct {
+		src     interface{}
+		want    SeasonYear
+		wantErr bool
+	}{
+		{nil, SeasonYear{}, false},
+		{"spring-2024", SeasonYear{Spring, 2024}, false},
+		{[]byte("autumn-2023"), SeasonYear{Autumn, 2023}, false},
+		{42, SeasonYear{}, true},
+	}
+	for _, tc := range cases {
+		var sy SeasonYear
+		err := sy.Scan(tc.src)
+		if (err != nil) != tc.wantErr || sy != tc.want {
+			t.Errorf("Scan(%v) = %v, %v", tc.src, sy, err)
+		}
+	}
+
+	if v, _ := (SeasonYear{}).Value(); v != nil {
+		t.Errorf("Value do vazio = %v, want nil", v)
+	}
+}
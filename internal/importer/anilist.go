@@ -0,0 +1,126 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"otamaker-api/internal/models"
+	"strconv"
+	"time"
+)
+
+// ==========================================================
+// ANILIST (Exportação JSON / GraphQL)
+// ==========================================================
+
+// anilistMedia: Objeto "Media" da API GraphQL do AniList.
+type anilistMedia struct {
+	ID    int64 `json:"id"`
+	Title struct {
+		Romaji  string `json:"romaji"`
+		English string `json:"english"`
+	} `json:"title"`
+	Genres    []string    `json:"genres"`
+	StartDate anilistDate `json:"startDate"`
+	EndDate   anilistDate `json:"endDate"`
+	Studios   struct {
+		Nodes []struct {
+			Name              string `json:"name"`
+			IsAnimationStudio bool   `json:"isAnimationStudio"`
+		} `json:"nodes"`
+	} `json:"studios"`
+	AverageScore *int   `json:"averageScore"` // Escala 0-100
+	Status       string `json:"status"`       // "RELEASING", "FINISHED"...
+}
+
+type anilistDate struct {
+	Year  *int `json:"year"`
+	Month *int `json:"month"`
+	Day   *int `json:"day"`
+}
+
+// anilistExport aceita os três formatos mais comuns:
+// lista pura de Media, resposta de Page (data.Page.media) e MediaListCollection (lists[].entries[].media).
+type anilistExport struct {
+	Data struct {
+		Page struct {
+			Media []anilistMedia `json:"media"`
+		} `json:"Page"`
+		MediaListCollection struct {
+			Lists []anilistList `json:"lists"`
+		} `json:"MediaListCollection"`
+	} `json:"data"`
+	Lists []anilistList `json:"lists"`
+}
+
+type anilistList struct {
+	Entries []struct {
+		Media anilistMedia `json:"media"`
+	} `json:"entries"`
+}
+
+// ParseAniList lê o JSON de exportação do AniList.
+func ParseAniList(r io.Reader) ([]Record, error) {
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var media []anilistMedia
+	if err := json.Unmarshal(raw, &media); err != nil {
+		var export anilistExport
+		if err := json.Unmarshal(raw, &export); err != nil {
+			return nil, fmt.Errorf("JSON do AniList inválido: %w", err)
+		}
+		media = export.Data.Page.Media
+		for _, l := range append(export.Data.MediaListCollection.Lists, export.Lists...) {
+			for _, e := range l.Entries {
+				media = append(media, e.Media)
+			}
+		}
+	}
+
+	records := make([]Record, 0, len(media))
+	for _, m := range media {
+		if m.ID == 0 {
+			continue
+		}
+		rec := Record{
+			Provider:     models.ProviderAniList,
+			ExternalID:   strconv.FormatInt(m.ID, 10),
+			TitleRomaji:  m.Title.Romaji,
+			TitleEnglish: m.Title.English,
+			Genres:       m.Genres,
+			Start:        m.StartDate.toTime(),
+			End:          m.EndDate.toTime(),
+			Airing:       m.Status == "RELEASING",
+		}
+		for _, s := range m.Studios.Nodes {
+			if s.IsAnimationStudio && s.Name != "" {
+				rec.Studios = append(rec.Studios, s.Name)
+			}
+		}
+		if m.AverageScore != nil && *m.AverageScore > 0 {
+			score := float32(*m.AverageScore) / 10
+			rec.Score = &score
+		}
+		records = append(records, rec)
+	}
+	return records, nil
+}
+
+// toTime: Datas parciais (só ano, ou ano+mês) caem no dia/mês 1.
+func (d anilistDate) toTime() *time.Time {
+	if d.Year == nil {
+		return nil
+	}
+	month, day := 1, 1
+	if d.Month != nil {
+		month = *d.Month
+	}
+	if d.Day != nil {
+		day = *d.Day
+	}
+	t := time.Date(*d.Year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	return &t
+}
@@ -0,0 +1,309 @@
+package importer
+
+import (
+	"fmt"
+	"io"
+	"otamaker-api/internal/constants"
+	"otamaker-api/internal/models"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ==========================================================
+// 1. REGISTRO NEUTRO (Saída dos parsers)
+// ==========================================================
+
+// Record: Anime lido de um arquivo de exportação, já independente do formato de origem.
+type Record struct {
+	Provider   models.ExternalProvider
+	ExternalID string
+
+	// Títulos: inglês oficial (en_us) e romaji (fallback do inglês e título padrão do pt_br).
+	// 'Anime.Name' só aceita chaves de constants.Language; o título nativo não é importado.
+	TitleRomaji  string
+	TitleEnglish string
+
+	Genres  []string // Texto cru (normalizado depois via NormalizeGenre)
+	Studios []string
+	Start   *time.Time
+	End     *time.Time
+	Score   *float32 // Escala 0-10
+	Airing  bool
+}
+
+// Parser: Lê um arquivo de exportação inteiro.
+type Parser func(r io.Reader) ([]Record, error)
+
+// ==========================================================
+// 2. PERSISTÊNCIA (Contrato)
+// ==========================================================
+
+// Store: O repositório real implementa com a tabela AnimeExternalID.
+// FindByExternalID devolve (nil, nil) quando o anime ainda não existe.
+// Save grava o anime e garante o vínculo (Provider, ExternalID) -> Anime.ID.
+type Store interface {
+	FindByExternalID(provider models.ExternalProvider, externalID string) (*models.Anime, error)
+	Save(anime *models.Anime, provider models.ExternalProvider, externalID string) error
+}
+
+// ==========================================================
+// 3. RELATÓRIO
+// ==========================================================
+
+// Report: Resumo da importação. Rodar o mesmo arquivo duas vezes deve gerar Created=0 e Updated=0.
+type Report struct {
+	Created   int
+	Updated   int
+	Unchanged int
+	Skipped   int
+	Rejected  int
+
+	// Incomplete: Animes novos recusados por faltar gênero reconhecido ou data de estreia (Regras 2 e 12 do Anime).
+	Incomplete []string
+
+	// UnknownGenres: Gêneros que NormalizeGenre não reconhece (texto -> ocorrências).
+	UnknownGenres map[string]int
+	Errors        []string
+}
+
+// Print escreve o relatório em formato legível (CLI).
+func (r *Report) Print(w io.Writer) {
+	fmt.Fprintf(w, "Criados: %d | Atualizados: %d | Sem mudança: %d | Ignorados: %d | Recusados: %d\n",
+		r.Created, r.Updated, r.Unchanged, r.Skipped, r.Rejected)
+
+	if len(r.UnknownGenres) > 0 {
+		names := make([]string, 0, len(r.UnknownGenres))
+		for g := range r.UnknownGenres {
+			names = append(names, g)
+		}
+		sort.Strings(names)
+
+		fmt.Fprintln(w, "Gêneros desconhecidos:")
+		for _, g := range names {
+			fmt.Fprintf(w, "  - %s (%d)\n", g, r.UnknownGenres[g])
+		}
+	}
+	if len(r.Incomplete) > 0 {
+		fmt.Fprintln(w, "Recusados (dados obrigatórios ausentes):")
+		for _, line := range r.Incomplete {
+			fmt.Fprintf(w, "  - %s\n", line)
+		}
+	}
+	for _, e := range r.Errors {
+		fmt.Fprintf(w, "ERRO: %s\n", e)
+	}
+}
+
+// ==========================================================
+// 4. IMPORTAÇÃO (Upsert idempotente)
+// ==========================================================
+
+// Run aplica os registros no Store. Erros de um registro não interrompem os demais.
+func Run(records []Record, store Store, now time.Time) *Report {
+	report := &Report{UnknownGenres: map[string]int{}}
+
+	for _, rec := range records {
+		if rec.ExternalID == "" || (rec.TitleRomaji == "" && rec.TitleEnglish == "") {
+			report.Skipped++
+			continue
+		}
+
+		existing, err := store.FindByExternalID(rec.Provider, rec.ExternalID)
+		if err != nil {
+			report.Errors = append(report.Errors, fmt.Sprintf("%s:%s: %v", rec.Provider, rec.ExternalID, err))
+			continue
+		}
+
+		anime := existing
+		if anime == nil {
+			anime = &models.Anime{
+				IsVisible: false, // Importados nascem ocultos até revisão editorial.
+				CreatedAt: now,
+			}
+		}
+
+		changed := apply(anime, rec, report)
+		if existing == nil {
+			if missing := missingRequired(anime); missing != "" {
+				report.Rejected++
+				report.Incomplete = append(report.Incomplete, fmt.Sprintf("%s:%s (%s): %s", rec.Provider, rec.ExternalID, title(rec), missing))
+				continue
+			}
+		}
+		if existing != nil && !changed {
+			report.Unchanged++
+			continue
+		}
+
+		context := "import:" + string(rec.Provider)
+		anime.LastUpdateContext = &context
+		if existing != nil {
+			anime.UpdatedAt = &now
+		}
+
+		if err := store.Save(anime, rec.Provider, rec.ExternalID); err != nil {
+			report.Errors = append(report.Errors, fmt.Sprintf("%s:%s: %v", rec.Provider, rec.ExternalID, err))
+			continue
+		}
+		if existing == nil {
+			report.Created++
+		} else {
+			report.Updated++
+		}
+	}
+	return report
+}
+
+// missingRequired: Campos obrigatórios de um anime novo que o registro não trouxe (vazio = completo).
+func missingRequired(a *models.Anime) string {
+	var missing []string
+	if len(a.Genres) == 0 {
+		missing = append(missing, "sem gênero reconhecido")
+	}
+	if a.FirstAired == nil {
+		missing = append(missing, "sem data de estreia")
+	}
+	return strings.Join(missing, ", ")
+}
+
+func title(rec Record) string {
+	if rec.TitleEnglish != "" {
+		return rec.TitleEnglish
+	}
+	return rec.TitleRomaji
+}
+
+// apply copia os dados do registro para o anime e diz se algo mudou.
+// Traduções manuais (pt_br, es_es) nunca são sobrescritas; apenas preenchidas se vazias.
+func apply(a *models.Anime, rec Record, report *Report) bool {
+	changed := false
+
+	if a.Name == nil {
+		a.Name = map[string]string{}
+	}
+	// Chaves fora de constants.Language (ex: "romaji", "ja" de importações antigas) saem do mapa.
+	for key := range a.Name {
+		if !constants.IsSupported(key) {
+			delete(a.Name, key)
+			changed = true
+		}
+	}
+	changed = setTitle(a.Name, string(constants.EN_US), title(rec), true) || changed
+	// No Brasil o título romanizado é o mais conhecido ("Shingeki no Kyojin").
+	changed = setTitle(a.Name, string(constants.Default), rec.TitleRomaji, false) || changed
+
+	genres := make([]constants.Genre, 0, len(rec.Genres))
+	for _, raw := range rec.Genres {
+		g, err := constants.NormalizeGenre(raw)
+		if err != nil {
+			report.UnknownGenres[strings.TrimSpace(raw)]++
+			continue
+		}
+		genres = appendGenre(genres, g)
+	}
+	if len(genres) > 0 && !sameGenres(a.Genres, genres) {
+		a.Genres = genres
+		a.RecomputeRating() // Ecchi eleva o piso da classificação.
+		changed = true
+	}
+
+	if len(rec.Studios) > 0 && !sameStrings(a.Studios, rec.Studios) {
+		a.Studios = rec.Studios
+		changed = true
+	}
+
+	if rec.Score != nil && (a.SourceScore == nil || *a.SourceScore != *rec.Score) {
+		score := *rec.Score
+		a.SourceScore = &score
+		changed = true
+	}
+
+	firstAiredChanged := !sameDate(a.FirstAired, rec.Start)
+	if rec.Start != nil && firstAiredChanged {
+		a.FirstAired = rec.Start
+		changed = true
+	}
+	if rec.End != nil && !sameDate(a.LastAired, rec.End) {
+		a.LastAired = rec.End
+		changed = true
+	}
+	if rec.Start != nil {
+		before := a.SeasonYear
+		a.FillSeason(firstAiredChanged, false)
+		changed = changed || before != a.SeasonYear
+	}
+
+	if a.IsAired != rec.Airing {
+		a.IsAired = rec.Airing
+		changed = true
+	}
+
+	return changed
+}
+
+// setTitle grava o título na chave. overwrite=false só preenche se estiver vazio.
+func setTitle(names map[string]string, key, value string, overwrite bool) bool {
+	if value == "" || names[key] == value {
+		return false
+	}
+	if !overwrite && names[key] != "" {
+		return false
+	}
+	names[key] = value
+	return true
+}
+
+func appendGenre(list []constants.Genre, g constants.Genre) []constants.Genre {
+	for _, item := range list {
+		if item == g {
+			return list
+		}
+	}
+	return append(list, g)
+}
+
+func sameGenres(a, b []constants.Genre) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func sameStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func sameDate(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+/*
+REGRAS DO IMPORTADOR:
+1.  Offline: Roda em Job/CLI com o Store do banco (SQLStore), nunca dentro de um request HTTP.
+    A CLI "import-anime" grava no banco; com -dry-run usa MemoryStore e apenas mostra o relatório.
+2.  Chave: O upsert é feito pelo ID externo (Provider + ExternalID). Título NÃO é chave.
+3.  Idempotência: Reimportar o mesmo arquivo não altera nada (Created=0, Updated=0).
+4.  I18N: Título inglês (ou romaji) -> en_us; romaji -> pt_br (só se vazio). Traduções manuais são preservadas.
+    'Name' só guarda chaves de constants.Language: "romaji"/"ja" legados são removidos e o título nativo não é importado.
+5.  Gêneros: Normalizados via NormalizeGenre. Os desconhecidos vão para o relatório, não para o banco.
+6.  Visibilidade: Animes novos nascem ocultos (IsVisible=false) até revisão editorial.
+7.  Temporada: Derivada de FirstAired (FillSeason).
+8.  Obrigatórios: Anime novo sem gênero reconhecido ou sem data de estreia é recusado (Rejected) e listado no relatório.
+*/
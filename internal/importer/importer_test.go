@@ -0,0 +1,131 @@
+package importer
+
+import (
+	"otamaker-api/internal/constants"
+	"otamaker-api/internal/models"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRun(t *testing.T) {
+	now := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	start := time.Date(1998, 4, 3, 0, 0, 0, 0, time.UTC)
+	complete := Record{Provider: models.ProviderMAL, ExternalID: "1", TitleRomaji: "Kaubōi Bibappu",
+		TitleEnglish: "Cowboy Bebop", Genres: []string{"Action", "Culinary Arts"}, Start: &start}
+	cases := []struct {
+		name           string
+		records        []Record
+		wantCreated    int
+		wantRejected   int
+		wantSkipped    int
+		wantIncomplete string
+	}{
+		{"completo", []Record{complete}, 1, 0, 0, ""},
+		{"sem título ou ID", []Record{{Provider: models.ProviderMAL, ExternalID: "2"}, {Provider: models.ProviderMAL, TitleRomaji: "X"}}, 0, 0, 2, ""},
+		{"sem gênero reconhecido", []Record{{Provider: models.ProviderMAL, ExternalID: "3", TitleRomaji: "Sem Gênero",
+			Genres: []string{"Culinary Arts"}, Start: &start}}, 0, 1, 0, "sem gênero reconhecido"},
+		{"sem data de estreia", []Record{{Provider: models.ProviderAniList, ExternalID: "4", TitleRomaji: "Sem Data",
+			Genres: []string{"Drama"}}}, 0, 1, 0, "sem data de estreia"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			report := Run(tc.records, NewMemoryStore(), now)
+			if report.Created != tc.wantCreated || report.Rejected != tc.wantRejected || report.Skipped != tc.wantSkipped {
+				t.Errorf("relatório = %+v", report)
+			}
+			if tc.wantIncomplete != "" && (len(report.Incomplete) != 1 || !strings.Contains(report.Incomplete[0], tc.wantIncomplete)) {
+				t.Errorf("Incomplete = %v, want %q", report.Incomplete, tc.wantIncomplete)
+			}
+		})
+	}
+}
+
+func TestRunIdempotentAndNameKeys(t *testing.T) {
+	now := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	start := time.Date(2013, 4, 7, 0, 0, 0, 0, time.UTC)
+	rec := Record{Provider: models.ProviderAniList, ExternalID: "16498", TitleRomaji: "Shingeki no Kyojin",
+		TitleEnglish: "Attack on Titan", Genres: []string{"Action", "Drama"}, Start: &start}
+	store := NewMemoryStore()
+
+	first := Run([]Record{rec}, store, now)
+	if first.Created != 1 || first.UnknownGenres == nil {
+		t.Fatalf("primeira importação = %+v", first)
+	}
+	second := Run([]Record{rec}, store, now.Add(time.Hour))
+	if second.Created != 0 || second.Updated != 0 || second.Unchanged != 1 {
+		t.Errorf("reimportação = %+v, want só Unchanged", second)
+	}
+
+	anime, _ := store.FindByExternalID(rec.Provider, rec.ExternalID)
+	for key := range anime.Name {
+		if !constants.IsSupported(key) {
+			t.Errorf("Name tem chave fora de constants.Language: %q", key)
+		}
+	}
+	if anime.Name["en_us"] != "Attack on Titan" || anime.Name["pt_br"] != "Shingeki no Kyojin" {
+		t.Errorf("Name = %v", anime.Name)
+	}
+	if anime.IsVisible || anime.SeasonYear.String() != "spring-2013" {
+		t.Errorf("IsVisible = %v, SeasonYear = %q", anime.IsVisible, anime.SeasonYear.String())
+	}
+}
+
+func TestApplyRemovesLegacyNameKeys(t *testing.T) {
+	start := time.Date(2013, 4, 7, 0, 0, 0, 0, time.UTC)
+	a := &models.Anime{Name: map[string]string{"romaji": "Shingeki no Kyojin", "ja": "進撃の巨人", "pt_br": "Ataque dos Titãs"}}
+	rec := Record{TitleRomaji: "Shingeki no Kyojin", TitleEnglish: "Attack on Titan", Genres: []string{"Action"}, Start: &start}
+
+	if !apply(a, rec, &Report{UnknownGenres: map[string]int{}}) {
+		t.Fatal("apply deveria marcar mudança")
+	}
+	if _, ok := a.Name["romaji"]; ok {
+		t.Errorf("chave romaji não foi removida: %v", a.Name)
+	}
+	if _, ok := a.Name["ja"]; ok {
+		t.Errorf("chave ja não foi removida: %v", a.Name)
+	}
+	if a.Name["pt_br"] != "Ataque dos Titãs" {
+		t.Errorf("tradução manual sobrescrita: %v", a.Name)
+	}
+}
+
+func TestParsers(t *testing.T) {
+	mal := `<myanimelist><anime>
+		<series_animedb_id>1</series_animedb_id><series_title>Cowboy Bebop</series_title>
+		<series_genres>Action, Sci-Fi</series_genres><series_start>1998-04-00</series_start>
+		<series_end>0000-00-00</series_end><series_score>8.75</series_score>
+	</anime></myanimelist>`
+	anilist := `{"data":{"Page":{"media":[{"id":1,"title":{"romaji":"Cowboy Bebop","english":"Cowboy Bebop"},
+		"genres":["Action"],"startDate":{"year":1998,"month":4,"day":3},"averageScore":86,"status":"FINISHED",
+		"studios":{"nodes":[{"name":"Sunrise","isAnimationStudio":true}]}}]}}}`
+	cases := []struct {
+		name  string
+		parse Parser
+		input string
+	}{
+		{"mal", ParseMAL, mal},
+		{"anilist", ParseAniList, anilist},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			records, err := tc.parse(strings.NewReader(tc.input))
+			if err != nil {
+				t.Fatalf("err = %v", err)
+			}
+			if len(records) != 1 {
+				t.Fatalf("records = %d, want 1", len(records))
+			}
+			rec := records[0]
+			if rec.ExternalID != "1" || rec.TitleRomaji != "Cowboy Bebop" || len(rec.Genres) == 0 {
+				t.Errorf("record = %+v", rec)
+			}
+			if rec.Start == nil || rec.Start.Year() != 1998 || rec.Start.Month() != time.April {
+				t.Errorf("Start = %v", rec.Start)
+			}
+			if rec.End != nil {
+				t.Errorf("End = %v, want nil", rec.End)
+			}
+		})
+	}
+}
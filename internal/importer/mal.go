@@ -0,0 +1,92 @@
+package importer
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"otamaker-api/internal/models"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ==========================================================
+// MYANIMELIST (Exportação XML)
+// ==========================================================
+
+// malExport: Estrutura do arquivo gerado em "Export My List" do MAL.
+// O export oficial só traz ID, título e tipo. Os campos series_* extras (datas, gêneros,
+// estúdios, nota) são opcionais e aproveitados quando o arquivo vem enriquecido.
+type malExport struct {
+	XMLName xml.Name   `xml:"myanimelist"`
+	Anime   []malAnime `xml:"anime"`
+}
+
+type malAnime struct {
+	ID      string `xml:"series_animedb_id"`
+	Title   string `xml:"series_title"`
+	English string `xml:"series_title_english"`
+	Start   string `xml:"series_start"`
+	End     string `xml:"series_end"`
+	Genres  string `xml:"series_genres"`  // Separados por vírgula
+	Studios string `xml:"series_studios"` // Separados por vírgula
+	Score   string `xml:"series_score"`   // Nota da comunidade (0-10)
+	Status  string `xml:"series_status"`  // "Currently Airing", "Finished Airing"...
+}
+
+// ParseMAL lê o XML de exportação do MyAnimeList.
+func ParseMAL(r io.Reader) ([]Record, error) {
+	var export malExport
+	if err := xml.NewDecoder(r).Decode(&export); err != nil {
+		return nil, fmt.Errorf("XML do MAL inválido: %w", err)
+	}
+
+	records := make([]Record, 0, len(export.Anime))
+	for _, a := range export.Anime {
+		rec := Record{
+			Provider:     models.ProviderMAL,
+			ExternalID:   strings.TrimSpace(a.ID),
+			TitleRomaji:  strings.TrimSpace(a.Title),
+			TitleEnglish: strings.TrimSpace(a.English),
+			Genres:       splitList(a.Genres),
+			Studios:      splitList(a.Studios),
+			Start:        parseMALDate(a.Start),
+			End:          parseMALDate(a.End),
+			Airing:       strings.EqualFold(strings.TrimSpace(a.Status), "Currently Airing"),
+		}
+		if score, err := strconv.ParseFloat(strings.TrimSpace(a.Score), 32); err == nil && score > 0 {
+			s := float32(score)
+			rec.Score = &s
+		}
+		records = append(records, rec)
+	}
+	return records, nil
+}
+
+// parseMALDate: O MAL usa "0000-00-00" (e partes zeradas) para datas desconhecidas.
+func parseMALDate(raw string) *time.Time {
+	raw = strings.TrimSpace(raw)
+	if raw == "" || strings.HasPrefix(raw, "0000") {
+		return nil
+	}
+	raw = strings.ReplaceAll(raw, "-00", "-01")
+	t, err := time.Parse("2006-01-02", raw)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
+
+func splitList(raw string) []string {
+	if strings.TrimSpace(raw) == "" {
+		return nil
+	}
+	parts := strings.Split(raw, ",")
+	out := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
@@ -0,0 +1,27 @@
+package importer
+
+import "otamaker-api/internal/models"
+
+// MemoryStore: Store em memória usado por "import-anime -dry-run".
+// Nada é persistido; serve para ver o relatório antes de importar com o SQLStore.
+type MemoryStore struct {
+	nextID int64
+	byKey  map[string]*models.Anime
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{byKey: map[string]*models.Anime{}}
+}
+
+func (m *MemoryStore) FindByExternalID(provider models.ExternalProvider, externalID string) (*models.Anime, error) {
+	return m.byKey[string(provider)+":"+externalID], nil
+}
+
+func (m *MemoryStore) Save(anime *models.Anime, provider models.ExternalProvider, externalID string) error {
+	if anime.ID == 0 {
+		m.nextID++
+		anime.ID = m.nextID
+	}
+	m.byKey[string(provider)+":"+externalID] = anime
+	return nil
+}
@@ -0,0 +1,135 @@
+package importer
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"otamaker-api/internal/models"
+	"time"
+)
+
+// SQLStore: Store sobre o banco (tabelas 'animes' e 'anime_external_ids', placeholders do Postgres).
+// Lê e grava apenas as colunas que o importador controla; contadores, imagens e sinopse ficam intactos.
+// O driver é registrado pelo binário (import em branco); este pacote só conhece database/sql.
+type SQLStore struct {
+	db *sql.DB
+}
+
+func NewSQLStore(db *sql.DB) *SQLStore {
+	return &SQLStore{db: db}
+}
+
+const selectImportedAnime = `
+SELECT a.id, a.name, a.id_genres, a.studios, COALESCE(a.season, ''), a.season_year, a.source_score,
+       a.first_aired, a.last_aired, a.is_airing, a.is_visible,
+       COALESCE(a.declared_rating, ''), COALESCE(a.content_rating, ''),
+       a.created_at, a.updated_at, a.last_update_context
+FROM anime_external_ids x
+JOIN animes a ON a.id = x.id_anime
+WHERE x.provider = $1 AND x.external_id = $2`
+
+// FindByExternalID: Mesmo contrato do Store.
+func (s *SQLStore) FindByExternalID(provider models.ExternalProvider, externalID string) (*models.Anime, error) {
+	var (
+		a                     models.Anime
+		name, genres, studios []byte
+	)
+	err := s.db.QueryRow(selectImportedAnime, string(provider), externalID).Scan(
+		&a.ID, &name, &genres, &studios, &a.Season, &a.SeasonYear, &a.SourceScore,
+		&a.FirstAired, &a.LastAired, &a.IsAired, &a.IsVisible, &a.DeclaredRating, &a.ContentRating,
+		&a.CreatedAt, &a.UpdatedAt, &a.LastUpdateContext,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := unmarshalColumn("name", name, &a.Name); err != nil {
+		return nil, err
+	}
+	if err := unmarshalColumn("id_genres", genres, &a.Genres); err != nil {
+		return nil, err
+	}
+	if err := unmarshalColumn("studios", studios, &a.Studios); err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+// Save grava o anime (INSERT quando ID = 0, senão UPDATE) e o vínculo externo na mesma transação.
+func (s *SQLStore) Save(anime *models.Anime, provider models.ExternalProvider, externalID string) error {
+	name, err := json.Marshal(nonNilMap(anime.Name))
+	if err != nil {
+		return err
+	}
+	genres, err := json.Marshal(anime.Genres)
+	if err != nil {
+		return err
+	}
+	studios, err := json.Marshal(anime.Studios)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if anime.ID == 0 {
+		err = tx.QueryRow(`
+INSERT INTO animes (name, synopsis, id_genres, studios, season, season_year, source_score,
+                    first_aired, last_aired, is_airing, is_visible, declared_rating, content_rating,
+                    created_at, last_update_context)
+VALUES ($1, '{}', $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+RETURNING id`,
+			name, genres, studios, anime.Season, anime.SeasonYear, anime.SourceScore,
+			anime.FirstAired, anime.LastAired, anime.IsAired, anime.IsVisible, anime.DeclaredRating, anime.ContentRating,
+			anime.CreatedAt, anime.LastUpdateContext,
+		).Scan(&anime.ID)
+	} else {
+		_, err = tx.Exec(`
+UPDATE animes SET name = $2, id_genres = $3, studios = $4, season = $5, season_year = $6, source_score = $7,
+                  first_aired = $8, last_aired = $9, is_airing = $10, content_rating = $11,
+                  updated_at = $12, last_update_context = $13
+WHERE id = $1`,
+			anime.ID, name, genres, studios, anime.Season, anime.SeasonYear, anime.SourceScore,
+			anime.FirstAired, anime.LastAired, anime.IsAired, anime.ContentRating,
+			anime.UpdatedAt, anime.LastUpdateContext,
+		)
+	}
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(`
+INSERT INTO anime_external_ids (id_anime, provider, external_id, created_at)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (provider, external_id) DO NOTHING`,
+		anime.ID, string(provider), externalID, time.Now(),
+	); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// unmarshalColumn decodifica uma coluna JSON (serializer:json). Coluna NULL mantém o valor zero.
+func unmarshalColumn(column string, data []byte, target interface{}) error {
+	if len(data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, target); err != nil {
+		return fmt.Errorf("coluna %s: %w", column, err)
+	}
+	return nil
+}
+
+func nonNilMap(m map[string]string) map[string]string {
+	if m == nil {
+		return map[string]string{}
+	}
+	return m
+}
@@ -16,7 +16,7 @@ type Anime struct {
 	IsAired     bool `json:"is_airing" db:"is_airing"`       // Se está em exibição atualmente.
 	IsVisible   bool `json:"is_visible" db:"is_visible"`     // Controle soft de exibição.
 	IsModerated bool `json:"is_moderated" db:"is_moderated"` // Bloqueio administrativo (Ban).
-	IsFeatured  bool `json:"is_featured" db:"is_featured"`   // Destaque na Home.
+	IsFeatured  bool `json:"is_featured" db:"is_featured"`   // Destaque na Home (controlado por FeatureSchedule).
 
 	// IMAGENS
 	ImageCoverURL        string `json:"image_cover_url" db:"image_cover_url"`
@@ -32,10 +32,17 @@ type Anime struct {
 	Genres []constants.Genre `json:"id_genres" db:"id_genres" gorm:"type:text[];serializer:json"`
 	// Season: Temporada de lançamento.
 	Season constants.Season `json:"season" db:"season"`
+	// SeasonYear: Temporada com ano ("winter-2026"). É o valor usado nos filtros por temporada.
+	SeasonYear constants.SeasonYear `json:"season_year" db:"season_year" gorm:"type:text;index"`
 	// Studios: Lista de estúdios produtores.
 	Studios []string `json:"studios" db:"studios" gorm:"type:text[];serializer:json"`
 	// Keywords: Cache de tags dinâmicas (ex: "cyberpunk", "time travel").
 	Keywords []string `json:"keywords" db:"keywords" gorm:"type:text[];serializer:json"`
+	// ContentRating: Efetivo (maior entre DeclaredRating e o piso dos gêneros, ver content_rating.go).
+	DeclaredRating ContentRating `json:"declared_rating" db:"declared_rating"`
+	ContentRating  ContentRating `json:"content_rating" db:"content_rating" gorm:"index"`
+	// IDFranchise: Universo ao qual a obra pertence (ex: "Naruto"). Ver franchise.go.
+	IDFranchise *int64 `json:"id_franchise" db:"id_franchise" gorm:"index"`
 
 	// DADOS EXTERNOS E DATAS
 	SourceScore *float32   `json:"source_score" db:"source_score"` // Nota do MAL/Anilist.
@@ -54,6 +61,8 @@ type Anime struct {
 	UpdatedAt         *time.Time `json:"updated_at" db:"updated_at"`
 	LastUpdateContext *string    `json:"last_update_context" db:"last_update_context"`
 	IDModeration      *int64     `json:"id_moderation" db:"id_moderation"`
+	// MergedIntoID: Preenchido quando este anime foi mesclado em outro (duplicado, ver anime_external.go).
+	MergedIntoID *int64 `json:"merged_into_id" db:"merged_into_id"`
 }
 
 // ==========================================================
@@ -65,6 +74,7 @@ type CreateAnimeInput struct {
 	Synopsis             map[string]string `json:"synopsis" binding:"required"`
 	Genres               []string          `json:"genres" binding:"required,min=1"`
 	Keywords             []string          `json:"keywords"`
+	Season               string            `json:"season"` // Opcional ("Verão 2025" ou "summer"). Se vazio, derivado de FirstAired (ApplySeasonInput).
 	FirstAired           string            `json:"first_aired" binding:"required,datetime=2006-01-02"`
 	LastAired            string            `json:"last_aired" binding:"omitempty,datetime=2006-01-02"`
 	ImageCoverURL        string            `json:"image_cover_url" binding:"required,url"`
@@ -72,6 +82,7 @@ type CreateAnimeInput struct {
 	IsAired              bool              `json:"is_airing"`
 	IsVisible            bool              `json:"is_visible"`
 	IsFeatured           bool              `json:"is_featured"`
+	ContentRating        ContentRating     `json:"content_rating" binding:"omitempty,oneof=safe suggestive adult"`
 }
 
 type UpdateAnimeInput struct {
@@ -79,7 +90,7 @@ type UpdateAnimeInput struct {
 	Synopsis             map[string]string `json:"synopsis"`
 	Genres               []string          `json:"genres"`
 	Keywords             []string          `json:"keywords"`
-	Season               constants.Season  `json:"season"`
+	Season               constants.Season  `json:"season"` // Vazio = mantém (ou deriva de FirstAired se ele mudar).
 	SourceScore          *float32          `json:"source_score"`
 	Studios              *[]string         `json:"studios"`
 	FirstAired           *string           `json:"first_aired" binding:"omitempty,datetime=2006-01-02"`
@@ -91,6 +102,147 @@ type UpdateAnimeInput struct {
 	IsVisible            *bool             `json:"is_visible"`
 	IsModerated          *bool             `json:"is_moderated"`
 	IDModeration         *int64            `json:"id_moderation"`
+	ContentRating        *ContentRating    `json:"content_rating" binding:"omitempty,oneof=safe suggestive adult"`
+}
+
+// ==========================================================
+// 3. DTOs (Resposta Pública)
+// ==========================================================
+
+// TranslatedResponse: Slug do banco + texto no idioma do cliente.
+type TranslatedResponse struct {
+	Slug string `json:"slug"`
+	Name string `json:"name"`
+}
+
+// SeasonResponse: Separa o valor canônico (filtros) do rótulo percebido pelo usuário.
+// Ex. (pt-BR): Slug "winter-2026", Name "Verão 2026", BroadcastName "Inverno 2026".
+type SeasonResponse struct {
+	Slug          string `json:"slug"`           // Calendário de exibição (canônico, usado nos filtros).
+	Name          string `json:"name"`           // Estação local do usuário, mês a mês (ex: "Verão/Outono" no Sul).
+	BroadcastName string `json:"broadcast_name"` // Nome da temporada da indústria, traduzido.
+}
+
+type AnimeResponse struct {
+	ID                   int64                `json:"id"`
+	Name                 string               `json:"name"`
+	Synopsis             string               `json:"synopsis"`
+	Genres               []TranslatedResponse `json:"genres"`
+	Season               *SeasonResponse      `json:"season"`
+	SeasonYear           *SeasonResponse      `json:"season_year"`
+	Studios              []string             `json:"studios"`
+	Keywords             []string             `json:"keywords"`
+	IDFranchise          *int64               `json:"id_franchise"`
+	ContentRating        ContentRating        `json:"content_rating"`
+	ImageCoverURL        string               `json:"image_cover_url"`
+	ImageCoverPreviewURL string               `json:"image_cover_preview_url"`
+	IsAired              bool                 `json:"is_airing"`
+	IsFeatured           bool                 `json:"is_featured"`
+	SourceScore          *float32             `json:"source_score"`
+	FirstAired           *time.Time           `json:"first_aired"`
+	LastAired            *time.Time           `json:"last_aired"`
+	MakersCount          uint64               `json:"makers_count"`
+	PacksCount           uint64               `json:"packs_count"`
+	StickersCount        uint64               `json:"stickers_count"`
+	PacksDownloadsCount  uint64               `json:"packs_downloads_count"`
+	PacksLikesCount      uint64               `json:"packs_likes_count"`
+}
+
+// AnimeSummaryResponse: Versão enxuta para embutir em Packs e listagens.
+type AnimeSummaryResponse struct {
+	ID                   int64  `json:"id"`
+	Name                 string `json:"name"`
+	ImageCoverPreviewURL string `json:"image_cover_preview_url"`
+}
+
+// Mapper (I18N)
+// Resolve Name/Synopsis via Language.Get e traduz Genres/Season.
+// Hemisfério derivado do idioma (pt_br = Sul). Use ToLocalizedResponse com o locale cru quando houver.
+func (a *Anime) ToResponse(lang constants.Language) AnimeResponse {
+	return a.ToLocalizedResponse(lang, lang.Hemisphere())
+}
+
+// Mapper (I18N + Hemisfério)
+func (a *Anime) ToLocalizedResponse(lang constants.Language, h constants.Hemisphere) AnimeResponse {
+	genres := make([]TranslatedResponse, 0, len(a.Genres))
+	for _, g := range a.Genres {
+		if g.IsValid() {
+			genres = append(genres, TranslatedResponse{Slug: string(g), Name: g.Translate(lang)})
+		}
+	}
+
+	var season *SeasonResponse
+	if a.Season.IsValid() {
+		s := NewSeasonResponse(a.Season, lang, h)
+		season = &s
+	}
+
+	var seasonYear *SeasonResponse
+	if !a.SeasonYear.IsZero() {
+		sy := NewSeasonYearResponse(a.SeasonYear, lang, h)
+		seasonYear = &sy
+	}
+
+	studios := a.Studios
+	if studios == nil {
+		studios = []string{}
+	}
+	keywords := a.Keywords
+	if keywords == nil {
+		keywords = []string{}
+	}
+
+	return AnimeResponse{
+		ID:                   a.ID,
+		Name:                 lang.Get(a.Name),
+		Synopsis:             lang.Get(a.Synopsis),
+		Genres:               genres,
+		Season:               season,
+		SeasonYear:           seasonYear,
+		Studios:              studios,
+		Keywords:             keywords,
+		IDFranchise:          a.IDFranchise,
+		ContentRating:        a.ContentRating.Normalize(),
+		ImageCoverURL:        a.ImageCoverURL,
+		ImageCoverPreviewURL: a.ImageCoverPreviewURL,
+		IsAired:              a.IsAired,
+		IsFeatured:           a.IsFeatured,
+		SourceScore:          a.SourceScore,
+		FirstAired:           a.FirstAired,
+		LastAired:            a.LastAired,
+		MakersCount:          a.MakersCount,
+		PacksCount:           a.PacksCount,
+		StickersCount:        a.StickersCount,
+		PacksDownloadsCount:  a.PacksDownloadsCount,
+		PacksLikesCount:      a.PacksLikesCount,
+	}
+}
+
+// NewSeasonResponse: Rótulos de uma temporada sem ano.
+func NewSeasonResponse(s constants.Season, lang constants.Language, h constants.Hemisphere) SeasonResponse {
+	return SeasonResponse{
+		Slug:          string(s),
+		Name:          s.DisplayName(h, lang),
+		BroadcastName: s.Translate(lang),
+	}
+}
+
+// NewSeasonYearResponse: Rótulos de uma temporada com ano.
+func NewSeasonYearResponse(sy constants.SeasonYear, lang constants.Language, h constants.Hemisphere) SeasonResponse {
+	return SeasonResponse{
+		Slug:          sy.String(),
+		Name:          sy.DisplayName(h, lang),
+		BroadcastName: sy.Translate(lang),
+	}
+}
+
+// Mapper (I18N)
+func (a *Anime) ToSummaryResponse(lang constants.Language) AnimeSummaryResponse {
+	return AnimeSummaryResponse{
+		ID:                   a.ID,
+		Name:                 lang.Get(a.Name),
+		ImageCoverPreviewURL: a.ImageCoverPreviewURL,
+	}
 }
 
 /*
@@ -105,10 +257,12 @@ REGRAS DO ANIME:
 8.  Ocultação: Pode ser marcado como oculto ('IsVisible=false') sem ser deletado.
 9. Moderação: Remoção ou bloqueio ('IsModerated') é ação exclusiva da moderação e exige um ID de registro (IDModeration).
 10. Temporada Atual: O sistema deve identificar animes da temporada atual baseando-se na data 'FirstAired' e flag 'IsAired'.
+    Se o cliente não enviar 'Season', ela é derivada de 'FirstAired'. Se enviar sem ano, o ano vem de 'FirstAired' (ApplySeasonInput/FillSeason, em anime_season.go).
 11. Performance: Dados de contagem (Makers, Packs, Stickers) devem ser denormalizados na entidade Anime para leitura rápida.
 12. Datas: Obrigatório ter data de lançamento (FirstAired). Encerramento é opcional (para animes em andamento).
 13. Score: Deve armazenar a nota de fontes externas (SourceScore) para fins de ordenação por qualidade.
 14. Força Maior: Animes moderados por DMCA ou infração grave tornam-se ocultos e bloqueados imediatamente.
 15. Hierarquia: Um anime pode ter pacotes, mas um pacote não obrigatoriamente precisa ter um anime (embora recomendado).
 16. API Pública: Animes ocultos ou moderados não devem retornar nas listagens padrão da API.
+17. Classificação: Gêneros como Ecchi elevam o piso de 'ContentRating'. Animes acima do teto do usuário não aparecem (content_rating.go).
 */
\ No newline at end of file
@@ -0,0 +1,280 @@
+package models
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"otamaker-api/internal/constants"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ==========================================================
+// 1. CATÁLOGO DE ANIMES (Filtros + Paginação por Cursor)
+// ==========================================================
+
+// AnimeSort: Critério de ordenação do catálogo (sempre decrescente, desempate por ID).
+type AnimeSort string
+
+const (
+	SortByScore     AnimeSort = "score"     // SourceScore
+	SortByPacks     AnimeSort = "packs"     // PacksCount
+	SortByDownloads AnimeSort = "downloads" // PacksDownloadsCount
+	SortByRecent    AnimeSort = "recent"    // FirstAired
+)
+
+const (
+	CatalogDefaultLimit = 20
+	CatalogMaxLimit     = 50
+)
+
+var ErrInvalidCursor = errors.New("cursor inválido")
+
+// AnimeCatalogQuery: Query string do endpoint público.
+// Genre e Season aceitam texto em qualquer idioma ("Ação", "acción", "Verão", "Verano 2025").
+type AnimeCatalogQuery struct {
+	Genre    string    `form:"genre"`
+	Season   string    `form:"season"`
+	Year     *int      `form:"year" binding:"omitempty,gte=1900,lte=2100"`
+	IsAired  *bool     `form:"is_airing"`
+	Studio   string    `form:"studio"`
+	MinScore *float32  `form:"min_score" binding:"omitempty,gte=0,lte=10"`
+	Sort     AnimeSort `form:"sort" binding:"omitempty,oneof=score packs downloads recent"`
+	Cursor   string    `form:"cursor"`
+	Limit    int       `form:"limit" binding:"omitempty,min=1,max=50"`
+}
+
+// AnimeCatalogFilter: Query já normalizada para slugs do banco.
+type AnimeCatalogFilter struct {
+	Genre      constants.Genre
+	Season     constants.Season
+	SeasonYear *constants.SeasonYear // Preenchido quando o cliente envia temporada com ano.
+	Year       *int
+	IsAired    *bool
+	Studio     string // lowercase
+	MinScore   *float32
+	Sort       AnimeSort
+	After      *catalogCursor
+	Limit      int
+	// MaxRating: Teto do usuário (ViewerMaxRating). Zero = Safe (anônimo).
+	MaxRating ContentRating
+}
+
+type catalogCursor struct {
+	Key float64
+	ID  int64
+}
+
+// AnimeCatalogPage: Página de resultados. NextCursor vazio = fim da lista.
+type AnimeCatalogPage struct {
+	Items      []AnimeResponse `json:"items"`
+	NextCursor string          `json:"next_cursor"`
+}
+
+// Normalize converte a entrada do cliente em filtro de banco.
+func (q AnimeCatalogQuery) Normalize() (AnimeCatalogFilter, error) {
+	f := AnimeCatalogFilter{
+		Year:     q.Year,
+		IsAired:  q.IsAired,
+		Studio:   strings.ToLower(strings.TrimSpace(q.Studio)),
+		MinScore: q.MinScore,
+		Sort:     q.Sort,
+		Limit:    q.Limit,
+	}
+
+	if q.Genre != "" {
+		g, err := constants.NormalizeGenre(q.Genre)
+		if err != nil {
+			return f, err
+		}
+		f.Genre = g
+	}
+	if q.Season != "" {
+		if sy, err := constants.ParseSeasonYear(q.Season); err == nil {
+			f.SeasonYear = &sy
+		} else {
+			s, err := constants.NormalizeSeason(q.Season)
+			if err != nil {
+				return f, err
+			}
+			f.Season = s
+		}
+	}
+	if f.Sort == "" {
+		f.Sort = SortByScore
+	}
+	if f.Limit <= 0 {
+		f.Limit = CatalogDefaultLimit
+	}
+	if f.Limit > CatalogMaxLimit {
+		f.Limit = CatalogMaxLimit
+	}
+	if q.Cursor != "" {
+		c, err := decodeCatalogCursor(q.Cursor)
+		if err != nil {
+			return f, err
+		}
+		f.After = &c
+	}
+	return f, nil
+}
+
+// Matches aplica os filtros a um anime. Ocultos, moderados e mesclados NUNCA passam (Regra 16).
+func (f *AnimeCatalogFilter) Matches(a *Anime) bool {
+	if !a.IsVisible || a.IsModerated || a.MergedIntoID != nil {
+		return false
+	}
+	if !f.MaxRating.Normalize().Allows(a.ContentRating) {
+		return false
+	}
+	if f.Genre != "" && !containsGenre(a.Genres, f.Genre) {
+		return false
+	}
+	if f.Season != "" && a.Season != f.Season {
+		return false
+	}
+	if f.SeasonYear != nil && a.SeasonYear != *f.SeasonYear {
+		return false
+	}
+	if f.Year != nil {
+		if year, ok := catalogYear(a); !ok || year != *f.Year {
+			return false
+		}
+	}
+	if f.IsAired != nil && a.IsAired != *f.IsAired {
+		return false
+	}
+	if f.Studio != "" && !containsFold(a.Studios, f.Studio) {
+		return false
+	}
+	if f.MinScore != nil && (a.SourceScore == nil || *a.SourceScore < *f.MinScore) {
+		return false
+	}
+	return true
+}
+
+// catalogYear: Ano da temporada de exibição ("winter-2026" = 2026, mesmo que a estreia seja em dezembro).
+// Registros sem SeasonYear (anteriores ao FillSeason) caem no ano de FirstAired.
+func catalogYear(a *Anime) (int, bool) {
+	if !a.SeasonYear.IsZero() {
+		return a.SeasonYear.Year, true
+	}
+	if a.FirstAired != nil {
+		return a.FirstAired.Year(), true
+	}
+	return 0, false
+}
+
+// SortKey: Valor numérico do critério de ordenação (usado também no cursor).
+func (f *AnimeCatalogFilter) SortKey(a *Anime) float64 {
+	switch f.Sort {
+	case SortByPacks:
+		return float64(a.PacksCount)
+	case SortByDownloads:
+		return float64(a.PacksDownloadsCount)
+	case SortByRecent:
+		if a.FirstAired == nil {
+			return 0
+		}
+		return float64(a.FirstAired.Unix())
+	default:
+		if a.SourceScore == nil {
+			return -1
+		}
+		return float64(*a.SourceScore)
+	}
+}
+
+// Paginate filtra, ordena (chave DESC, ID DESC) e corta a página após o cursor.
+// Espelha o SQL: WHERE (key, id) < (:key, :id) ORDER BY key DESC, id DESC LIMIT :limit + 1.
+func (f *AnimeCatalogFilter) Paginate(animes []Anime, lang constants.Language) AnimeCatalogPage {
+	matched := make([]*Anime, 0, len(animes))
+	for i := range animes {
+		a := &animes[i]
+		if !f.Matches(a) {
+			continue
+		}
+		if f.After != nil {
+			key := f.SortKey(a)
+			if key > f.After.Key || (key == f.After.Key && a.ID >= f.After.ID) {
+				continue
+			}
+		}
+		matched = append(matched, a)
+	}
+
+	sort.Slice(matched, func(i, j int) bool {
+		ki, kj := f.SortKey(matched[i]), f.SortKey(matched[j])
+		if ki != kj {
+			return ki > kj
+		}
+		return matched[i].ID > matched[j].ID
+	})
+
+	page := AnimeCatalogPage{Items: make([]AnimeResponse, 0, f.Limit)}
+	for i, a := range matched {
+		if i == f.Limit {
+			last := matched[i-1]
+			page.NextCursor = encodeCatalogCursor(catalogCursor{Key: f.SortKey(last), ID: last.ID})
+			break
+		}
+		page.Items = append(page.Items, a.ToResponse(lang))
+	}
+	return page
+}
+
+// Cursor opaco: base64("chave:id").
+func encodeCatalogCursor(c catalogCursor) string {
+	raw := strconv.FormatFloat(c.Key, 'g', -1, 64) + ":" + strconv.FormatInt(c.ID, 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeCatalogCursor(s string) (catalogCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return catalogCursor{}, ErrInvalidCursor
+	}
+	keyStr, idStr, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return catalogCursor{}, ErrInvalidCursor
+	}
+	key, err := strconv.ParseFloat(keyStr, 64)
+	if err != nil {
+		return catalogCursor{}, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return catalogCursor{}, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+	return catalogCursor{Key: key, ID: id}, nil
+}
+
+func containsGenre(list []constants.Genre, g constants.Genre) bool {
+	for _, item := range list {
+		if item == g {
+			return true
+		}
+	}
+	return false
+}
+
+// containsFold: Comparação case-insensitive (needle já em lowercase).
+func containsFold(list []string, needle string) bool {
+	for _, item := range list {
+		if strings.ToLower(item) == needle {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+REGRAS DO CATÁLOGO:
+1.  Visibilidade: Animes com IsVisible=false, IsModerated=true ou mesclados (MergedIntoID) nunca aparecem, independente dos filtros.
+2.  Entrada Multilíngue: Gênero e temporada são normalizados (NormalizeGenre/ParseSeasonYear/NormalizeSeason) antes de filtrar.
+3.  Ordenação: Sempre decrescente pelo critério escolhido, com ID como desempate estável.
+4.  Paginação: Cursor opaco (chave + ID). Não usa OFFSET, então inserções não duplicam nem pulam itens.
+5.  Limite: Padrão 20, máximo 50 por página.
+6.  Ano: 'year' filtra pelo ano da temporada (SeasonYear), o mesmo usado em "winter 2026". FirstAired só vale para registros legados.
+*/
@@ -0,0 +1,136 @@
+package models
+
+import (
+	"errors"
+	"otamaker-api/internal/constants"
+	"testing"
+	"time"
+)
+
+func TestAnimeCatalogNormalize(t *testing.T) {
+	cases := []struct {
+		name       string
+		query      AnimeCatalogQuery
+		wantErr    bool
+		wantGenre  constants.Genre
+		wantSeason constants.Season
+		wantSY     string
+		wantLimit  int
+	}{
+		{"padrões", AnimeCatalogQuery{}, false, "", "", "", CatalogDefaultLimit},
+		{"gênero traduzido", AnimeCatalogQuery{Genre: "Ação"}, false, constants.Acao, "", "", CatalogDefaultLimit},
+		{"temporada sem ano", AnimeCatalogQuery{Season: "Verão"}, false, "", constants.Summer, "", CatalogDefaultLimit},
+		{"temporada com ano", AnimeCatalogQuery{Season: "Verano 2025"}, false, "", "", "summer-2025", CatalogDefaultLimit},
+		{"limite acima do máximo", AnimeCatalogQuery{Limit: 500}, false, "", "", "", CatalogMaxLimit},
+		{"gênero desconhecido", AnimeCatalogQuery{Genre: "culinária"}, true, "", "", "", 0},
+		{"cursor inválido", AnimeCatalogQuery{Cursor: "%%%"}, true, "", "", "", 0},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			f, err := tc.query.Normalize()
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			if f.Genre != tc.wantGenre || f.Season != tc.wantSeason || f.Limit != tc.wantLimit || f.Sort != SortByScore {
+				t.Errorf("filtro = %+v", f)
+			}
+			gotSY := ""
+			if f.SeasonYear != nil {
+				gotSY = f.SeasonYear.String()
+			}
+			if gotSY != tc.wantSY {
+				t.Errorf("SeasonYear = %q, want %q", gotSY, tc.wantSY)
+			}
+		})
+	}
+}
+
+func TestAnimeCatalogMatches(t *testing.T) {
+	// Estreia em 28/12/2025, mas a temporada de exibição é winter-2026.
+	aired := time.Date(2025, 12, 28, 0, 0, 0, 0, time.UTC)
+	base := func() Anime {
+		return Anime{ID: 1, IsVisible: true, Genres: []constants.Genre{constants.Acao}, FirstAired: &aired,
+			Season: constants.Winter, SeasonYear: constants.SeasonYear{Season: constants.Winter, Year: 2026},
+			Studios: []string{"MAPPA"}}
+	}
+	year := func(y int) *int { return &y }
+	merged := int64(2)
+	cases := []struct {
+		name   string
+		filter AnimeCatalogFilter
+		mutate func(a *Anime)
+		want   bool
+	}{
+		{"sem filtros", AnimeCatalogFilter{}, nil, true},
+		{"oculto", AnimeCatalogFilter{}, func(a *Anime) { a.IsVisible = false }, false},
+		{"mesclado", AnimeCatalogFilter{}, func(a *Anime) { a.MergedIntoID = &merged }, false},
+		{"acima do teto", AnimeCatalogFilter{}, func(a *Anime) { a.ContentRating = RatingSuggestive }, false},
+		{"ano da temporada", AnimeCatalogFilter{Year: year(2026)}, nil, true},
+		{"ano da estreia não vale", AnimeCatalogFilter{Year: year(2025)}, nil, false},
+		{"legado sem SeasonYear usa FirstAired", AnimeCatalogFilter{Year: year(2025)}, func(a *Anime) { a.SeasonYear = constants.SeasonYear{} }, true},
+		{"estúdio sem caixa", AnimeCatalogFilter{Studio: "mappa"}, nil, true},
+		{"gênero ausente", AnimeCatalogFilter{Genre: constants.Drama}, nil, false},
+		{"temporada com ano", AnimeCatalogFilter{SeasonYear: &constants.SeasonYear{Season: constants.Winter, Year: 2026}}, nil, true},
+	}
+	for _, tc := range cases {
+		a := base()
+		if tc.mutate != nil {
+			tc.mutate(&a)
+		}
+		if got := tc.filter.Matches(&a); got != tc.want {
+			t.Errorf("%s: Matches = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestAnimeCatalogPaginate(t *testing.T) {
+	score := func(v float32) *float32 { return &v }
+	animes := []Anime{
+		{ID: 1, IsVisible: true, SourceScore: score(7)},
+		{ID: 2, IsVisible: true, SourceScore: score(9)},
+		{ID: 3, IsVisible: true, SourceScore: score(9)},
+		{ID: 4, IsVisible: true},
+		{ID: 5, IsVisible: false, SourceScore: score(10)},
+	}
+	f, err := AnimeCatalogQuery{Limit: 2}.Normalize()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []int64
+	for pages := 0; pages < 5; pages++ {
+		page := f.Paginate(animes, constants.PT_BR)
+		for _, item := range page.Items {
+			got = append(got, item.ID)
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		if f, err = (AnimeCatalogQuery{Limit: 2, Cursor: page.NextCursor}).Normalize(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want := []int64{3, 2, 1, 4}
+	if len(got) != len(want) {
+		t.Fatalf("ordem = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("ordem = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestDecodeCatalogCursor(t *testing.T) {
+	c := catalogCursor{Key: 8.5, ID: 42}
+	got, err := decodeCatalogCursor(encodeCatalogCursor(c))
+	if err != nil || got != c {
+		t.Errorf("ida e volta = %+v, %v", got, err)
+	}
+	if _, err := decodeCatalogCursor("bm9wZQ"); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("sem separador = %v, want ErrInvalidCursor", err)
+	}
+}
@@ -0,0 +1,439 @@
+package models
+
+import (
+	"errors"
+	"strconv"
+	"time"
+)
+
+// ==========================================================
+// 1. IDs EXTERNOS (Referência Cruzada)
+// ==========================================================
+
+// ExternalProvider: Base de dados externa de animes.
+type ExternalProvider string
+
+const (
+	ProviderMAL     ExternalProvider = "mal"
+	ProviderAniList ExternalProvider = "anilist"
+	ProviderKitsu   ExternalProvider = "kitsu"
+	ProviderAniDB   ExternalProvider = "anidb"
+)
+
+var providers = map[ExternalProvider]bool{
+	ProviderMAL:     true,
+	ProviderAniList: true,
+	ProviderKitsu:   true,
+	ProviderAniDB:   true,
+}
+
+// IsValid verifica se o provedor é suportado.
+func (p ExternalProvider) IsValid() bool {
+	return providers[p]
+}
+
+var (
+	ErrMergeSameAnime = errors.New("não é possível mesclar um anime com ele mesmo")
+	ErrMergeConflict  = errors.New("os dois animes possuem IDs diferentes no mesmo provedor")
+	ErrMergeChain     = errors.New("anime já foi mesclado em outro")
+)
+
+// AnimeExternalID: Vincula um Anime aos IDs dele no MAL, AniList, Kitsu e AniDB.
+// Regra: (Provider, ExternalID) é único. Um anime tem no máximo um ID por provedor.
+type AnimeExternalID struct {
+	ID int64 `json:"id" db:"id" gorm:"primaryKey"`
+
+	IDAnime    int64            `json:"id_anime" db:"id_anime" gorm:"index;uniqueIndex:idx_anime_provider"`
+	Provider   ExternalProvider `json:"provider" db:"provider" gorm:"uniqueIndex:idx_provider_external;uniqueIndex:idx_anime_provider"`
+	ExternalID string           `json:"external_id" db:"external_id" gorm:"uniqueIndex:idx_provider_external"`
+
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// ==========================================================
+// 2. INPUTS E DTOs
+// ==========================================================
+
+// ExternalIDLookupInput: Busca o anime pelo ID do provedor (ex: /animes/lookup?provider=mal&external_id=1).
+type ExternalIDLookupInput struct {
+	Provider   ExternalProvider `form:"provider" binding:"required,oneof=mal anilist kitsu anidb"`
+	ExternalID string           `form:"external_id" binding:"required,max=32"`
+}
+
+type LinkExternalIDInput struct {
+	Provider   ExternalProvider `json:"provider" binding:"required,oneof=mal anilist kitsu anidb"`
+	ExternalID string           `json:"external_id" binding:"required,max=32"`
+}
+
+// MergeAnimeInput: Ferramenta Admin. O duplicado é absorvido pelo canônico.
+type MergeAnimeInput struct {
+	IDCanonical int64 `json:"id_canonical" binding:"required,gt=0"`
+	IDDuplicate int64 `json:"id_duplicate" binding:"required,gt=0,nefield=IDCanonical"`
+}
+
+type ExternalIDResponse struct {
+	Provider   ExternalProvider `json:"provider"`
+	ExternalID string           `json:"external_id"`
+	URL        string           `json:"url"`
+}
+
+// URL: Link público da página do anime no provedor.
+func (e *AnimeExternalID) URL() string {
+	switch e.Provider {
+	case ProviderMAL:
+		return "https://myanimelist.net/anime/" + e.ExternalID
+	case ProviderAniList:
+		return "https://anilist.co/anime/" + e.ExternalID
+	case ProviderKitsu:
+		return "https://kitsu.app/anime/" + e.ExternalID
+	case ProviderAniDB:
+		return "https://anidb.net/anime/" + e.ExternalID
+	default:
+		return ""
+	}
+}
+
+// Mapper
+func (e *AnimeExternalID) ToResponse() ExternalIDResponse {
+	return ExternalIDResponse{Provider: e.Provider, ExternalID: e.ExternalID, URL: e.URL()}
+}
+
+// ==========================================================
+// 3. MESCLAGEM (Duplicado -> Canônico)
+// ==========================================================
+
+// AnimeMergeSet: Tudo que pertence aos dois animes, carregado pelo Service antes da mesclagem.
+// Packs e Stickers devem incluir os dos DOIS animes (para recalcular os contadores)
+// e também os de outros animes marcados como spoiler do duplicado (SpoilerAnimeID).
+type AnimeMergeSet struct {
+	Packs          []*Pack
+	Stickers       []*Sticker
+	Keywords       []AnimeKeyword      // Pivots dos dois animes.
+	ExternalIDs    []*AnimeExternalID  // IDs externos dos dois animes.
+	Relations      []AnimeRelation     // Arestas (nas duas direções) que tocam qualquer um dos dois.
+	Characters     []*Character        // Personagens do duplicado.
+	SpoilerSafety  []MakerAnimeSpoiler // Progresso dos Makers nos dois animes.
+	FeatureWindows []*FeatureSchedule  // Janelas de destaque com alvo no duplicado.
+}
+
+// AnimeMergeResult: O que o Service deve gravar.
+// AnimeRelation tem chave composta: re-apontar = DELETE da aresta antiga + INSERT da nova.
+type AnimeMergeResult struct {
+	Keywords        []AnimeKeyword // Pivots finais do canônico (sem duplicatas).
+	DroppedKeywords []AnimeKeyword // Pivots do duplicado que já existiam no canônico (DELETE).
+
+	DeleteRelations []AnimeRelation // Todas as arestas do duplicado.
+	InsertRelations []AnimeRelation // As mesmas, apontando para o canônico (sem laços nem repetidas).
+
+	// MakerAnimeSpoiler também tem chave composta (IDMaker, IDAnime): mesmo contrato das relações.
+	DeleteSpoilerSafety []MakerAnimeSpoiler // Linhas do duplicado.
+	UpsertSpoilerSafety []MakerAnimeSpoiler // Linha final do canônico para cada Maker afetado.
+
+	// FeatureWindows: Janelas re-apontadas para o canônico (UPDATE). PlanFeatureFlags ajusta 'IsFeatured' na próxima rodada.
+	FeatureWindows []*FeatureSchedule
+
+	// Franchises: Franquias a recalcular (RollUp) após gravar.
+	Franchises []int64
+}
+
+// MergeAnimes funde o duplicado no canônico.
+// Re-aponta Pack.IDAnime, Sticker.IDAnime, Character.IDAnime, AnimeKeyword, AnimeRelation, IDFranchise e AnimeExternalID,
+// além dos spoilers (SpoilerAnimeID, MakerAnimeSpoiler) e das janelas de destaque,
+// une os metadados, recalcula classificação e contadores e deixa o duplicado oculto apontando para o canônico.
+func MergeAnimes(canonical, duplicate *Anime, set AnimeMergeSet, now time.Time) (AnimeMergeResult, error) {
+	var result AnimeMergeResult
+	if canonical.ID == duplicate.ID {
+		return result, ErrMergeSameAnime
+	}
+	// Sem cadeias: mesclar num anime já mesclado (ou mesclar de novo o duplicado) quebraria o redirecionamento.
+	if canonical.MergedIntoID != nil || duplicate.MergedIntoID != nil {
+		return result, ErrMergeChain
+	}
+
+	// IDs externos: mesmo provedor com IDs diferentes indica que NÃO são o mesmo anime.
+	byProvider := map[ExternalProvider]string{}
+	for _, e := range set.ExternalIDs {
+		if e.IDAnime == canonical.ID {
+			byProvider[e.Provider] = e.ExternalID
+		}
+	}
+	for _, e := range set.ExternalIDs {
+		if e.IDAnime != duplicate.ID {
+			continue
+		}
+		if id, ok := byProvider[e.Provider]; ok && id != e.ExternalID {
+			return result, ErrMergeConflict
+		}
+	}
+	for _, e := range set.ExternalIDs {
+		if e.IDAnime == duplicate.ID {
+			e.IDAnime = canonical.ID
+		}
+	}
+
+	for _, p := range set.Packs {
+		if p.IDAnime == duplicate.ID {
+			p.IDAnime = canonical.ID
+			p.UpdatedAt = now
+		}
+		if repointID(&p.SpoilerAnimeID, duplicate.ID, canonical.ID) {
+			p.UpdatedAt = now
+		}
+	}
+	for _, s := range set.Stickers {
+		if repointID(&s.IDAnime, duplicate.ID, canonical.ID) {
+			s.UpdatedAt = now
+		}
+		if repointID(&s.SpoilerAnimeID, duplicate.ID, canonical.ID) {
+			s.UpdatedAt = now
+		}
+	}
+
+	result.DeleteSpoilerSafety, result.UpsertSpoilerSafety = repointSpoilerSafety(set.SpoilerSafety, duplicate.ID, canonical.ID, now)
+
+	for _, w := range set.FeatureWindows {
+		if w.TargetType == TargetAnime && w.IDTarget == duplicate.ID {
+			w.IDTarget = canonical.ID
+			w.UpdatedAt = now
+			result.FeatureWindows = append(result.FeatureWindows, w)
+		}
+	}
+
+	seen := map[int64]bool{}
+	for _, k := range set.Keywords {
+		if k.IDAnime == canonical.ID {
+			seen[k.IDKeyword] = true
+			result.Keywords = append(result.Keywords, k)
+		}
+	}
+	for _, k := range set.Keywords {
+		if k.IDAnime != duplicate.ID {
+			continue
+		}
+		if seen[k.IDKeyword] {
+			result.DroppedKeywords = append(result.DroppedKeywords, k)
+			continue
+		}
+		seen[k.IDKeyword] = true
+		k.IDAnime = canonical.ID
+		result.Keywords = append(result.Keywords, k)
+	}
+
+	for _, c := range set.Characters {
+		if c.IDAnime == duplicate.ID {
+			c.IDAnime = canonical.ID
+			c.UpdatedAt = now
+		}
+	}
+
+	result.DeleteRelations, result.InsertRelations = repointRelations(set.Relations, duplicate.ID, canonical.ID, now)
+
+	// Franquia: o canônico herda a do duplicado se não tiver uma. As duas (se diferentes) são recalculadas.
+	for _, f := range []*int64{canonical.IDFranchise, duplicate.IDFranchise} {
+		if f != nil && !containsID(result.Franchises, *f) {
+			result.Franchises = append(result.Franchises, *f)
+		}
+	}
+	if canonical.IDFranchise == nil {
+		canonical.IDFranchise = duplicate.IDFranchise
+	}
+	duplicate.IDFranchise = nil
+
+	mergeAnimeMetadata(canonical, duplicate)
+	canonical.RecomputeRating()
+
+	packs := make([]Pack, 0, len(set.Packs))
+	for _, p := range set.Packs {
+		packs = append(packs, *p)
+	}
+	stickers := make([]Sticker, 0, len(set.Stickers))
+	for _, s := range set.Stickers {
+		stickers = append(stickers, *s)
+	}
+	canonical.RecomputeCounters(packs, stickers)
+	canonical.UpdatedAt = &now
+
+	canonicalID := canonical.ID
+	context := "merged_into:" + strconv.FormatInt(canonicalID, 10)
+	duplicate.MergedIntoID = &canonicalID
+	duplicate.IsVisible = false
+	duplicate.IsFeatured = false
+	duplicate.MakersCount, duplicate.PacksCount, duplicate.StickersCount = 0, 0, 0
+	duplicate.PacksDownloadsCount, duplicate.PacksLikesCount = 0, 0
+	duplicate.LastUpdateContext = &context
+	duplicate.UpdatedAt = &now
+
+	return result, nil
+}
+
+// repointID troca 'from' por 'to' com um ponteiro novo (o antigo pode ser compartilhado). Diz se houve troca.
+func repointID(id **int64, from, to int64) bool {
+	if *id == nil || **id != from {
+		return false
+	}
+	*id = &to
+	return true
+}
+
+// repointSpoilerSafety move o progresso do duplicado para o canônico.
+// Se o Maker já tinha progresso nos dois, fica o mais avançado (episódio maior; terminou vence).
+func repointSpoilerSafety(rows []MakerAnimeSpoiler, from, to int64, now time.Time) (del, upsert []MakerAnimeSpoiler) {
+	current := map[int64]MakerAnimeSpoiler{}
+	for _, r := range rows {
+		if r.IDAnime == to {
+			current[r.IDMaker] = r
+		}
+	}
+	for _, r := range rows {
+		if r.IDAnime != from {
+			continue
+		}
+		del = append(del, r)
+		merged, ok := current[r.IDMaker]
+		if !ok {
+			merged = MakerAnimeSpoiler{IDMaker: r.IDMaker, IDAnime: to}
+		}
+		if r.SafeUpToEpisode > merged.SafeUpToEpisode {
+			merged.SafeUpToEpisode = r.SafeUpToEpisode
+		}
+		merged.HasFinished = merged.HasFinished || r.HasFinished
+		merged.UpdatedAt = now
+		upsert = append(upsert, merged)
+	}
+	return del, upsert
+}
+
+// repointRelations troca o duplicado pelo canônico nas arestas.
+// Arestas entre os dois viram laço e somem; arestas que o canônico já tem não são duplicadas.
+func repointRelations(relations []AnimeRelation, from, to int64, now time.Time) (del, ins []AnimeRelation) {
+	type edge struct{ a, b int64 }
+	has := map[edge]bool{}
+	for _, r := range relations {
+		if r.IDAnime != from && r.IDAnimeRelated != from {
+			has[edge{r.IDAnime, r.IDAnimeRelated}] = true
+		}
+	}
+	for _, r := range relations {
+		if r.IDAnime != from && r.IDAnimeRelated != from {
+			continue
+		}
+		del = append(del, r)
+		if r.IDAnime == from {
+			r.IDAnime = to
+		}
+		if r.IDAnimeRelated == from {
+			r.IDAnimeRelated = to
+		}
+		key := edge{r.IDAnime, r.IDAnimeRelated}
+		if r.IDAnime == r.IDAnimeRelated || has[key] {
+			continue
+		}
+		has[key] = true
+		r.CreatedAt = now
+		ins = append(ins, r)
+	}
+	return del, ins
+}
+
+// mergeAnimeMetadata: O canônico manda; o duplicado só preenche lacunas e soma listas.
+// A classificação declarada fica com a mais restritiva (o Service chama RecomputeRating em seguida).
+func mergeAnimeMetadata(canonical, duplicate *Anime) {
+	canonical.DeclaredRating = MaxRating(canonical.DeclaredRating, duplicate.DeclaredRating)
+	canonical.Name = fillMissing(canonical.Name, duplicate.Name)
+	canonical.Synopsis = fillMissing(canonical.Synopsis, duplicate.Synopsis)
+
+	for _, g := range duplicate.Genres {
+		if !containsGenre(canonical.Genres, g) {
+			canonical.Genres = append(canonical.Genres, g)
+		}
+	}
+	canonical.Studios = unionStrings(canonical.Studios, duplicate.Studios)
+	canonical.Keywords = unionStrings(canonical.Keywords, duplicate.Keywords)
+
+	if canonical.SourceScore == nil {
+		canonical.SourceScore = duplicate.SourceScore
+	}
+	if canonical.FirstAired == nil {
+		canonical.FirstAired = duplicate.FirstAired
+	}
+	if canonical.LastAired == nil {
+		canonical.LastAired = duplicate.LastAired
+	}
+	if canonical.SeasonYear.IsZero() && canonical.Season == "" {
+		canonical.Season = duplicate.Season
+		canonical.SeasonYear = duplicate.SeasonYear
+	}
+}
+
+// RecomputeCounters recalcula os contadores denormalizados a partir das linhas reais.
+// Usado em mesclagens e correções (o fluxo normal é incremental).
+func (a *Anime) RecomputeCounters(packs []Pack, stickers []Sticker) {
+	makers := map[int64]bool{}
+	a.PacksCount, a.StickersCount, a.PacksDownloadsCount, a.PacksLikesCount = 0, 0, 0, 0
+
+	for i := range packs {
+		p := &packs[i]
+		if p.IDAnime != a.ID || p.IsDeleted || !p.IsPublished() {
+			continue
+		}
+		a.PacksCount++
+		a.PacksDownloadsCount += p.DownloadsCount
+		a.PacksLikesCount += p.LikesCount
+		makers[p.IDMaker] = true
+	}
+	for i := range stickers {
+		s := &stickers[i]
+		if s.IDAnime != nil && *s.IDAnime == a.ID && !s.IsDeleted {
+			a.StickersCount++
+		}
+	}
+	a.MakersCount = uint64(len(makers))
+}
+
+func containsID(list []int64, id int64) bool {
+	for _, item := range list {
+		if item == id {
+			return true
+		}
+	}
+	return false
+}
+
+func fillMissing(dst, src map[string]string) map[string]string {
+	if dst == nil {
+		dst = map[string]string{}
+	}
+	for k, v := range src {
+		if dst[k] == "" && v != "" {
+			dst[k] = v
+		}
+	}
+	return dst
+}
+
+func unionStrings(a, b []string) []string {
+	seen := make(map[string]bool, len(a)+len(b))
+	for _, s := range a {
+		seen[s] = true
+	}
+	for _, s := range b {
+		if !seen[s] {
+			seen[s] = true
+			a = append(a, s)
+		}
+	}
+	return a
+}
+
+/*
+REGRAS DE IDs EXTERNOS E MESCLAGEM:
+1.  Unicidade: (Provider, ExternalID) é único no banco. Um anime tem no máximo um ID por provedor.
+2.  Busca: A API permite achar o anime pelo ID do provedor (usado pelo importador e por integrações).
+3.  Mesclagem (Admin): O duplicado é absorvido pelo canônico. Packs, Stickers, Personagens, AnimeKeyword, relações, franquia e IDs externos são re-apontados.
+    Spoilers (SpoilerAnimeID de packs/stickers e o progresso MakerAnimeSpoiler) e janelas de destaque também.
+4.  Conflito: Se os dois tiverem IDs diferentes no mesmo provedor, a mesclagem é recusada (provavelmente são obras diferentes).
+5.  Contadores: Após mesclar, os contadores do canônico são recalculados a partir das linhas (RecomputeCounters), assim como a classificação (RecomputeRating) e as franquias envolvidas (RollUp).
+6.  Redirecionamento: O duplicado fica oculto com 'MergedIntoID'. Links antigos redirecionam para o canônico.
+7.  Sem Cadeias: Anime já mesclado não participa de outra mesclagem (nem como canônico, nem como duplicado).
+8.  Metadados: O canônico manda. Lacunas (datas, nota, temporada) são preenchidas pelo duplicado e as listas são somadas.
+*/
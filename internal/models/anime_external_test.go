@@ -0,0 +1,118 @@
+package models
+
+import (
+	"errors"
+	"otamaker-api/internal/constants"
+	"testing"
+	"time"
+)
+
+func TestMergeAnimesRejects(t *testing.T) {
+	now := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	other := int64(99)
+	cases := []struct {
+		name      string
+		canonical Anime
+		duplicate Anime
+		set       AnimeMergeSet
+		wantErr   error
+	}{
+		{"mesmo anime", Anime{ID: 1}, Anime{ID: 1}, AnimeMergeSet{}, ErrMergeSameAnime},
+		{"canônico já mesclado", Anime{ID: 1, MergedIntoID: &other}, Anime{ID: 2}, AnimeMergeSet{}, ErrMergeChain},
+		{"duplicado já mesclado", Anime{ID: 1}, Anime{ID: 2, MergedIntoID: &other}, AnimeMergeSet{}, ErrMergeChain},
+		{"IDs diferentes no mesmo provedor", Anime{ID: 1}, Anime{ID: 2}, AnimeMergeSet{ExternalIDs: []*AnimeExternalID{
+			{IDAnime: 1, Provider: ProviderMAL, ExternalID: "10"},
+			{IDAnime: 2, Provider: ProviderMAL, ExternalID: "11"},
+		}}, ErrMergeConflict},
+	}
+	for _, tc := range cases {
+		if _, err := MergeAnimes(&tc.canonical, &tc.duplicate, tc.set, now); !errors.Is(err, tc.wantErr) {
+			t.Errorf("%s: err = %v, want %v", tc.name, err, tc.wantErr)
+		}
+	}
+}
+
+func TestMergeAnimes(t *testing.T) {
+	now := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	dupID, canonID := int64(2), int64(1)
+	spoilerDup := dupID
+	stickerAnime := dupID
+	canonical := &Anime{ID: canonID, Name: map[string]string{"en_us": "Attack on Titan"}, Genres: []constants.Genre{constants.Acao}}
+	duplicate := &Anime{ID: dupID, Name: map[string]string{"pt_br": "Ataque dos Titãs"}, Genres: []constants.Genre{constants.Drama},
+		Season: constants.Spring, SeasonYear: constants.SeasonYear{Season: constants.Spring, Year: 2013}, IsFeatured: true, PacksCount: 3}
+	set := AnimeMergeSet{
+		Packs: []*Pack{
+			{ID: 10, IDAnime: dupID, IDMaker: 7, Status: PackPublished},
+			{ID: 11, IDAnime: 5, SpoilerAnimeID: &spoilerDup}, // Outro anime com spoiler do duplicado.
+		},
+		Stickers:    []*Sticker{{ID: 20, IDAnime: &stickerAnime, SpoilerAnimeID: &spoilerDup}},
+		Keywords:    []AnimeKeyword{{IDAnime: canonID, IDKeyword: 1}, {IDAnime: dupID, IDKeyword: 1}, {IDAnime: dupID, IDKeyword: 2}},
+		ExternalIDs: []*AnimeExternalID{{IDAnime: dupID, Provider: ProviderAniList, ExternalID: "16498"}},
+		Relations: []AnimeRelation{
+			{IDAnime: dupID, IDAnimeRelated: 3, Type: RelationSequel},
+			{IDAnime: canonID, IDAnimeRelated: dupID, Type: RelationSideStory},
+		},
+		SpoilerSafety: []MakerAnimeSpoiler{
+			{IDMaker: 7, IDAnime: canonID, SafeUpToEpisode: 5},
+			{IDMaker: 7, IDAnime: dupID, SafeUpToEpisode: 12},
+			{IDMaker: 8, IDAnime: dupID, HasFinished: true},
+		},
+		FeatureWindows: []*FeatureSchedule{
+			{ID: 30, TargetType: TargetAnime, IDTarget: dupID},
+			{ID: 31, TargetType: TargetPack, IDTarget: dupID}, // Pack com o mesmo ID numérico: não é o anime.
+		},
+	}
+
+	result, err := MergeAnimes(canonical, duplicate, set, now)
+	if err != nil {
+		t.Fatalf("err = %v", err)
+	}
+
+	checks := []struct {
+		name string
+		ok   bool
+	}{
+		{"pack re-apontado", set.Packs[0].IDAnime == canonID},
+		{"spoiler de pack re-apontado", *set.Packs[1].SpoilerAnimeID == canonID},
+		{"sticker re-apontado", *set.Stickers[0].IDAnime == canonID && *set.Stickers[0].SpoilerAnimeID == canonID},
+		{"ponteiro antigo intacto", spoilerDup == dupID && stickerAnime == dupID},
+		{"ID externo re-apontado", set.ExternalIDs[0].IDAnime == canonID},
+		{"keyword repetida descartada", len(result.DroppedKeywords) == 1 && len(result.Keywords) == 2},
+		{"laço removido", len(result.DeleteRelations) == 2 && len(result.InsertRelations) == 1},
+		{"progresso do duplicado apagado", len(result.DeleteSpoilerSafety) == 2},
+		{"janela de anime re-apontada", len(result.FeatureWindows) == 1 && set.FeatureWindows[0].IDTarget == canonID},
+		{"janela de pack intacta", set.FeatureWindows[1].IDTarget == dupID},
+		{"temporada herdada", canonical.SeasonYear.String() == "spring-2013" && canonical.Season == constants.Spring},
+		{"nome e gêneros somados", canonical.Name["pt_br"] == "Ataque dos Titãs" && len(canonical.Genres) == 2},
+		{"contadores recalculados", canonical.PacksCount == 1 && canonical.StickersCount == 1 && canonical.MakersCount == 1},
+		{"duplicado redireciona", duplicate.MergedIntoID != nil && *duplicate.MergedIntoID == canonID && !duplicate.IsVisible && !duplicate.IsFeatured},
+		{"contadores do duplicado zerados", duplicate.PacksCount == 0},
+	}
+	for _, c := range checks {
+		if !c.ok {
+			t.Errorf("%s", c.name)
+		}
+	}
+
+	progress := map[int64]MakerAnimeSpoiler{}
+	for _, r := range result.UpsertSpoilerSafety {
+		if r.IDAnime != canonID {
+			t.Errorf("progresso gravado no anime %d", r.IDAnime)
+		}
+		progress[r.IDMaker] = r
+	}
+	if progress[7].SafeUpToEpisode != 12 || !progress[8].HasFinished {
+		t.Errorf("progresso = %+v", progress)
+	}
+}
+
+func TestMergeKeepsCanonicalSeason(t *testing.T) {
+	canonical := &Anime{ID: 1, Season: constants.Winter, SeasonYear: constants.SeasonYear{Season: constants.Winter, Year: 2020}}
+	duplicate := &Anime{ID: 2, Season: constants.Spring, SeasonYear: constants.SeasonYear{Season: constants.Spring, Year: 2013}}
+	if _, err := MergeAnimes(canonical, duplicate, AnimeMergeSet{}, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	if canonical.SeasonYear.String() != "winter-2020" {
+		t.Errorf("SeasonYear = %q, want winter-2020", canonical.SeasonYear.String())
+	}
+}
@@ -0,0 +1,134 @@
+package models
+
+import (
+	"otamaker-api/internal/constants"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ==========================================================
+// 1. TEMPORADA AUTOMÁTICA (Regra 10)
+// ==========================================================
+
+// FillSeason mantém 'Season' e 'SeasonYear' coerentes.
+// Sem temporada (ou com FirstAired alterado), ambos são derivados de 'FirstAired'.
+// Com temporada explícita sem ano, o ano vem de 'FirstAired' (SeasonYear nunca fica vazio com Season preenchida).
+// firstAiredChanged: o request alterou FirstAired (no Create é sempre true).
+// seasonExplicit: o cliente enviou a temporada neste request (tem prioridade sobre a derivação).
+func (a *Anime) FillSeason(firstAiredChanged, seasonExplicit bool) {
+	if a.Season == "" && !a.SeasonYear.IsZero() {
+		a.Season = a.SeasonYear.Season
+	}
+	if a.FirstAired == nil {
+		return
+	}
+	if a.Season == "" || (firstAiredChanged && !seasonExplicit) {
+		a.SeasonYear = constants.SeasonYearOf(*a.FirstAired)
+		a.Season = a.SeasonYear.Season
+		return
+	}
+	if a.SeasonYear.Season != a.Season || (firstAiredChanged && a.SeasonYear.Year == 0) {
+		a.SeasonYear = constants.SeasonYear{Season: a.Season, Year: a.FirstAired.Year()}
+	}
+}
+
+// ApplySeasonInput interpreta o 'Season' livre do CreateAnimeInput e completa via FillSeason.
+// Aceita temporada com ano em qualquer idioma ("Verão 2025", "summer-2025") ou só a estação ("summer", "Verano").
+// Vazio = derivado de 'FirstAired'.
+func (a *Anime) ApplySeasonInput(input string, firstAiredChanged bool) error {
+	if strings.TrimSpace(input) == "" {
+		a.FillSeason(firstAiredChanged, false)
+		return nil
+	}
+	if sy, err := constants.ParseSeasonYear(input); err == nil {
+		a.SeasonYear = sy
+		a.Season = sy.Season
+	} else {
+		season, err := constants.NormalizeSeason(input)
+		if err != nil {
+			return err
+		}
+		a.Season = season
+		a.SeasonYear = constants.SeasonYear{}
+	}
+	a.FillSeason(firstAiredChanged, true)
+	return nil
+}
+
+// ==========================================================
+// 2. LINEUP DA TEMPORADA (Atual e Anterior)
+// ==========================================================
+
+type SeasonLineupResponse struct {
+	Season SeasonResponse  `json:"season"`
+	Year   int             `json:"year"`
+	Animes []AnimeResponse `json:"animes"`
+}
+
+type CurrentSeasonResponse struct {
+	Current  SeasonLineupResponse `json:"current"`
+	Previous SeasonLineupResponse `json:"previous"`
+}
+
+// BuildCurrentSeason separa os animes públicos nas temporadas atual e anterior a partir de 'FirstAired'.
+// Na temporada atual entram apenas os que estão em exibição (IsAired) ou ainda vão estrear.
+// Ordem: mais populares primeiro (PacksCount), desempate por SourceScore.
+// h: hemisfério do usuário, usado só nos rótulos (a separação em temporadas é sempre a canônica).
+// ceiling: ViewerMaxRating do usuário. Animes acima do teto ficam de fora.
+func BuildCurrentSeason(animes []Anime, now time.Time, lang constants.Language, h constants.Hemisphere, ceiling ContentRating) CurrentSeasonResponse {
+	animes = FilterAnimesByRating(animes, ceiling)
+	curStart, curEnd := constants.SeasonBounds(now)
+	prevStart, prevEnd := constants.SeasonBounds(constants.PreviousSeasonDate(now))
+
+	var current, previous []*Anime
+	for i := range animes {
+		a := &animes[i]
+		if !a.IsVisible || a.IsModerated || a.FirstAired == nil {
+			continue
+		}
+		switch aired := *a.FirstAired; {
+		case inRange(aired, curStart, curEnd):
+			if a.IsAired || aired.After(now) {
+				current = append(current, a)
+			}
+		case inRange(aired, prevStart, prevEnd):
+			previous = append(previous, a)
+		}
+	}
+
+	return CurrentSeasonResponse{
+		Current:  buildLineup(current, curStart, lang, h),
+		Previous: buildLineup(previous, prevStart, lang, h),
+	}
+}
+
+func buildLineup(list []*Anime, start time.Time, lang constants.Language, h constants.Hemisphere) SeasonLineupResponse {
+	sort.SliceStable(list, func(i, j int) bool {
+		if list[i].PacksCount != list[j].PacksCount {
+			return list[i].PacksCount > list[j].PacksCount
+		}
+		return scoreOf(list[i]) > scoreOf(list[j])
+	})
+
+	out := SeasonLineupResponse{
+		Season: NewSeasonYearResponse(constants.SeasonYearOf(start), lang, h),
+		Year:   start.Year(),
+		Animes: make([]AnimeResponse, 0, len(list)),
+	}
+	for _, a := range list {
+		out.Animes = append(out.Animes, a.ToLocalizedResponse(lang, h))
+	}
+	return out
+}
+
+func inRange(t, start, end time.Time) bool {
+	return !t.Before(start) && t.Before(end)
+}
+
+func scoreOf(a *Anime) float32 {
+	if a.SourceScore == nil {
+		return 0
+	}
+	return *a.SourceScore
+}
@@ -0,0 +1,119 @@
+package models
+
+import (
+	"otamaker-api/internal/constants"
+	"testing"
+	"time"
+)
+
+func TestApplySeasonInput(t *testing.T) {
+	aired := time.Date(2025, 7, 5, 0, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name     string
+		input    string
+		wantErr  bool
+		wantSlug string
+	}{
+		{"vazio deriva de FirstAired", "", false, "summer-2025"},
+		{"estação com ano em português", "Verão 2024", false, "summer-2024"},
+		{"estação sem ano usa o ano de FirstAired", "autumn", false, "autumn-2025"},
+		{"estação sem ano em espanhol", "Invierno", false, "winter-2025"},
+		{"texto inválido", "monção", true, ""},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := &Anime{FirstAired: &aired}
+			err := a.ApplySeasonInput(tc.input, true)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			if got := a.SeasonYear.String(); got != tc.wantSlug {
+				t.Errorf("SeasonYear = %q, want %q", got, tc.wantSlug)
+			}
+			if a.Season != a.SeasonYear.Season {
+				t.Errorf("Season = %q fora de sincronia com %q", a.Season, a.SeasonYear.Season)
+			}
+		})
+	}
+}
+
+func TestFillSeason(t *testing.T) {
+	aired := time.Date(2026, 2, 10, 0, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name              string
+		anime             Anime
+		firstAiredChanged bool
+		seasonExplicit    bool
+		want              string
+	}{
+		{"deriva quando vazio", Anime{FirstAired: &aired}, true, false, "winter-2026"},
+		{"data nova rederiva a temporada", Anime{FirstAired: &aired, Season: constants.Summer,
+			SeasonYear: constants.SeasonYear{Season: constants.Summer, Year: 2025}}, true, false, "winter-2026"},
+		{"temporada explícita vence a data", Anime{FirstAired: &aired, Season: constants.Autumn}, true, true, "autumn-2026"},
+		{"sem mudança mantém", Anime{FirstAired: &aired, Season: constants.Spring,
+			SeasonYear: constants.SeasonYear{Season: constants.Spring, Year: 2024}}, false, false, "spring-2024"},
+		{"sem FirstAired só espelha Season", Anime{SeasonYear: constants.SeasonYear{Season: constants.Spring, Year: 2024}}, true, false, "spring-2024"},
+	}
+	for _, tc := range cases {
+		a := tc.anime
+		a.FillSeason(tc.firstAiredChanged, tc.seasonExplicit)
+		if got := a.SeasonYear.String(); got != tc.want || a.Season != a.SeasonYear.Season {
+			t.Errorf("%s: SeasonYear = %q Season = %q, want %q", tc.name, got, a.Season, tc.want)
+		}
+	}
+}
+
+func TestBuildCurrentSeason(t *testing.T) {
+	now := time.Date(2026, 2, 10, 0, 0, 0, 0, time.UTC)
+	date := func(y int, m time.Month, d int) *time.Time {
+		v := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+		return &v
+	}
+	animes := []Anime{
+		{ID: 1, IsVisible: true, IsAired: true, FirstAired: date(2026, 1, 8), PacksCount: 2},
+		{ID: 2, IsVisible: true, IsAired: true, FirstAired: date(2026, 1, 9), PacksCount: 5},
+		{ID: 3, IsVisible: true, IsAired: false, FirstAired: date(2026, 1, 3)},  // Já estreou e encerrou.
+		{ID: 4, IsVisible: true, IsAired: false, FirstAired: date(2026, 3, 20)}, // Estreia futura.
+		{ID: 5, IsVisible: true, FirstAired: date(2025, 10, 2)},
+		{ID: 6, IsVisible: false, IsAired: true, FirstAired: date(2026, 1, 8)},
+		{ID: 7, IsVisible: true, IsAired: true, FirstAired: date(2026, 1, 8), ContentRating: RatingAdult},
+		{ID: 8, IsVisible: true, FirstAired: date(2025, 6, 1)},
+	}
+	cases := []struct {
+		name         string
+		ceiling      ContentRating
+		hemisphere   constants.Hemisphere
+		wantCurrent  []int64
+		wantPrevious []int64
+		wantSlug     string
+	}{
+		{"anônimo no norte", RatingSafe, constants.North, []int64{2, 1, 4}, []int64{5}, "winter-2026"},
+		{"adulto no sul", RatingAdult, constants.South, []int64{2, 1, 4, 7}, []int64{5}, "winter-2026"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			out := BuildCurrentSeason(animes, now, constants.PT_BR, tc.hemisphere, tc.ceiling)
+			if out.Current.Season.Slug != tc.wantSlug || out.Previous.Season.Slug != "autumn-2025" {
+				t.Errorf("slugs = %q / %q", out.Current.Season.Slug, out.Previous.Season.Slug)
+			}
+			assertAnimeIDs(t, "current", out.Current.Animes, tc.wantCurrent)
+			assertAnimeIDs(t, "previous", out.Previous.Animes, tc.wantPrevious)
+		})
+	}
+}
+
+func assertAnimeIDs(t *testing.T, label string, got []AnimeResponse, want []int64) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Errorf("%s: %d animes, want %v", label, len(got), want)
+		return
+	}
+	for i := range want {
+		if got[i].ID != want[i] {
+			t.Errorf("%s[%d] = %d, want %d", label, i, got[i].ID, want[i])
+		}
+	}
+}
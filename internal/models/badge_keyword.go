@@ -0,0 +1,243 @@
+package models
+
+import (
+	"errors"
+	"sort"
+	"time"
+)
+
+// ==========================================================
+// 1. BADGES POR KEYWORD ("Fã de One Piece")
+// ==========================================================
+
+// Tipos de requisito que olham para keywords em vez de contadores do Maker.
+const (
+	// BadgeReqKeywordWeight: MakerKeyword.Weight >= RequirementValue na keyword RequirementKeywordID.
+	BadgeReqKeywordWeight = "keyword_weight"
+	// BadgeReqCharacterStickers: RequirementValue stickers criados com o personagem RequirementKeywordID.
+	BadgeReqCharacterStickers = "character_stickers"
+)
+
+var (
+	ErrBadgeKeywordRequired  = errors.New("badge por keyword exige 'req_keyword_id'")
+	ErrBadgeKeywordNotFound  = errors.New("keyword do requisito não existe ou está pendente")
+	ErrBadgeKeywordCharacter = errors.New("requisito 'character_stickers' exige uma keyword de personagem")
+	ErrBadgeWeightRange      = errors.New("peso do requisito deve estar entre 1 e 100")
+)
+
+// IsKeywordBadge: O requisito depende de keyword (avaliado por EvaluateKeywordBadges).
+func (b *Badge) IsKeywordBadge() bool {
+	return b.RequirementType == BadgeReqKeywordWeight || b.RequirementType == BadgeReqCharacterStickers
+}
+
+// ValidateKeywordRequirement: Checagem do Admin ao criar/editar a Badge. keywords: ID -> Keyword.
+func (b *Badge) ValidateKeywordRequirement(keywords map[int64]Keyword) error {
+	if !b.IsKeywordBadge() {
+		return nil
+	}
+	if b.RequirementKeywordID == nil || *b.RequirementKeywordID <= 0 {
+		return ErrBadgeKeywordRequired
+	}
+	k, ok := keywords[*b.RequirementKeywordID]
+	if !ok || k.IsPending {
+		return ErrBadgeKeywordNotFound
+	}
+	switch b.RequirementType {
+	case BadgeReqKeywordWeight:
+		if b.RequirementValue < 1 || b.RequirementValue > 100 {
+			return ErrBadgeWeightRange
+		}
+	case BadgeReqCharacterStickers:
+		if k.Category != CategoryCharacter {
+			return ErrBadgeKeywordCharacter
+		}
+	}
+	return nil
+}
+
+// ==========================================================
+// 2. AVALIADOR
+// ==========================================================
+
+// KeywordBadgeInput: Estado do Maker carregado pelo Service.
+type KeywordBadgeInput struct {
+	IDMaker int64
+	Badges  []Badge      // Todas as badges por keyword ativas.
+	Owned   []MakerBadge // Badges que o Maker já tem.
+	Weights []MakerKeyword
+
+	// CharacterStickers: Keyword de personagem -> stickers criados (ver CountCharacterStickers).
+	CharacterStickers map[int64]uint64
+
+	Keywords map[int64]Keyword // ID -> Keyword (resolve RequirementKeywordID).
+}
+
+// CountCharacterStickers: Stickers criados (crédito autoral) por keyword CategoryCharacter.
+// Apagados, moderados e privados (IsVisible=false) não contam, como na especialidade.
+func CountCharacterStickers(stickers []Sticker, keywords map[string]Keyword) map[int64]uint64 {
+	out := map[int64]uint64{}
+	for _, s := range stickers {
+		if !countsForExpertise(&s) {
+			continue
+		}
+		seen := map[int64]bool{}
+		for _, slug := range s.Keywords {
+			k, ok := keywords[slug]
+			if ok && k.Category == CategoryCharacter && !seen[k.ID] {
+				seen[k.ID] = true
+				out[k.ID]++
+			}
+		}
+	}
+	return out
+}
+
+// EvaluateKeywordBadges devolve as badges novas do Maker (o Service grava e notifica).
+// Badges conquistadas nunca são revogadas, mesmo que o peso decaia depois.
+func EvaluateKeywordBadges(in KeywordBadgeInput, now time.Time) []MakerBadge {
+	owned := make(map[int64]bool, len(in.Owned))
+	for _, mb := range in.Owned {
+		owned[mb.IDBadge] = true
+	}
+	weights := make(map[int64]int, len(in.Weights))
+	for _, mk := range in.Weights {
+		weights[mk.IDKeyword] = mk.Weight
+	}
+
+	var earned []MakerBadge
+	for _, b := range in.Badges {
+		if owned[b.ID] || !b.IsKeywordBadge() || b.RequirementKeywordID == nil {
+			continue
+		}
+		k, ok := in.Keywords[*b.RequirementKeywordID]
+		if !ok || k.IsPending {
+			continue
+		}
+
+		var reached bool
+		switch b.RequirementType {
+		case BadgeReqKeywordWeight:
+			reached = weights[k.ID] >= b.RequirementValue
+		case BadgeReqCharacterStickers:
+			reached = k.Category == CategoryCharacter && in.CharacterStickers[k.ID] >= uint64(b.RequirementValue)
+		}
+		if reached {
+			earned = append(earned, MakerBadge{IDMaker: in.IDMaker, IDBadge: b.ID, EarnedAt: now})
+			owned[b.ID] = true
+		}
+	}
+	return earned
+}
+
+// KeywordBadgesTouched: Se alguma mudança de peso (DiffMakerKeywords) pode liberar badge.
+// Evita carregar badges e inventário quando o Job só baixou pesos.
+func KeywordBadgesTouched(changes []ExpertiseChange) bool {
+	for _, c := range changes {
+		if c.New > c.Old {
+			return true
+		}
+	}
+	return false
+}
+
+// StickerBadgesTouched: Gatilho de "character_stickers". Chamado após criar o sticker e após TagSticker.
+// Se true, o Service recarrega CountCharacterStickers do autor e chama EvaluateKeywordBadges.
+func StickerBadgesTouched(s *Sticker, keywords map[string]Keyword) bool {
+	if !countsForExpertise(s) {
+		return false
+	}
+	for _, slug := range s.Keywords {
+		if k, ok := keywords[slug]; ok && k.Category == CategoryCharacter {
+			return true
+		}
+	}
+	return false
+}
+
+// ==========================================================
+// 3. BADGE NOVA OU EDITADA (Retroativo)
+// ==========================================================
+
+// KeywordBadgeChanged: O conjunto de badges mudou de um jeito que pode liberar conquistas
+// (badge criada, ou requisito alterado). before nil = criação.
+func KeywordBadgeChanged(before, after *Badge) bool {
+	if after == nil || !after.IsKeywordBadge() {
+		return false
+	}
+	if before == nil {
+		return true
+	}
+	return before.RequirementType != after.RequirementType ||
+		before.RequirementValue != after.RequirementValue ||
+		!sameIDPtr(before.RequirementKeywordID, after.RequirementKeywordID)
+}
+
+// KeywordBadgeBackfillInput: Makers que podem já cumprir o requisito da badge (carregados pelo Service).
+type KeywordBadgeBackfillInput struct {
+	Badge   Badge
+	Keyword Keyword      // Keyword de RequirementKeywordID.
+	Owned   []MakerBadge // Quem já tem a badge.
+
+	// Weights: MakerKeyword da keyword alvo ("keyword_weight").
+	Weights []MakerKeyword
+	// CharacterStickers: IDMaker -> stickers criados com o personagem ("character_stickers").
+	CharacterStickers map[int64]uint64
+}
+
+// BackfillKeywordBadge concede a badge recém-criada (ou editada) a quem já cumpre o requisito.
+// Sem isso, quem já tinha o peso nunca a receberia (o Job só avalia quando um peso sobe).
+func BackfillKeywordBadge(in KeywordBadgeBackfillInput, now time.Time) []MakerBadge {
+	b := in.Badge
+	if !b.IsKeywordBadge() || b.RequirementKeywordID == nil || *b.RequirementKeywordID != in.Keyword.ID || in.Keyword.IsPending {
+		return nil
+	}
+	owned := make(map[int64]bool, len(in.Owned))
+	for _, mb := range in.Owned {
+		owned[mb.IDMaker] = true
+	}
+
+	var earned []MakerBadge
+	grant := func(idMaker int64) {
+		if !owned[idMaker] {
+			owned[idMaker] = true
+			earned = append(earned, MakerBadge{IDMaker: idMaker, IDBadge: b.ID, EarnedAt: now})
+		}
+	}
+	switch b.RequirementType {
+	case BadgeReqKeywordWeight:
+		for _, mk := range in.Weights {
+			if mk.IDKeyword == in.Keyword.ID && mk.Weight >= b.RequirementValue {
+				grant(mk.IDMaker)
+			}
+		}
+	case BadgeReqCharacterStickers:
+		if in.Keyword.Category != CategoryCharacter {
+			return nil
+		}
+		for idMaker, n := range in.CharacterStickers {
+			if n >= uint64(b.RequirementValue) {
+				grant(idMaker)
+			}
+		}
+		sort.Slice(earned, func(i, j int) bool { return earned[i].IDMaker < earned[j].IDMaker })
+	}
+	return earned
+}
+
+func sameIDPtr(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+/*
+REGRAS DE BADGES POR KEYWORD:
+1.  Tipos: "keyword_weight" (peso em MakerKeyword >= X na keyword Y) e "character_stickers" (N stickers criados com o personagem Z).
+2.  Alvo: 'Badge.RequirementKeywordID' guarda o ID da keyword. A keyword deve existir, estar curada e, para "character_stickers", ser CategoryCharacter.
+3.  Gatilho: O Job de especialidade avalia quando algum peso sobe (KeywordBadgesTouched). Criar ou marcar sticker com personagem avalia
+    "character_stickers" (StickerBadgesTouched). Criar ou editar o requisito da badge concede retroativamente (KeywordBadgeChanged + BackfillKeywordBadge).
+4.  Autoria: Stickers contam pelo crédito autoral (OriginalMakerID). Apagados, moderados e privados não contam.
+5.  Permanência: Badge conquistada não é revogada quando o peso decai.
+6.  Merge: Se a keyword alvo for mesclada, o requisito é re-apontado para o destino automaticamente (MergeKeywords).
+*/
@@ -0,0 +1,172 @@
+package models
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func idPtr(id int64) *int64 { return &id }
+
+func TestValidateKeywordRequirement(t *testing.T) {
+	keywords := map[int64]Keyword{
+		1: {ID: 1, Slug: "one_piece", Category: CategoryWork},
+		2: {ID: 2, Slug: "luffy", Category: CategoryCharacter},
+		3: {ID: 3, Slug: "novo", IsPending: true},
+	}
+	cases := []struct {
+		name    string
+		badge   Badge
+		wantErr error
+	}{
+		{"badge comum", Badge{RequirementType: "packs_created_count"}, nil},
+		{"sem keyword", Badge{RequirementType: BadgeReqKeywordWeight, RequirementValue: 80}, ErrBadgeKeywordRequired},
+		{"keyword pendente", Badge{RequirementType: BadgeReqKeywordWeight, RequirementValue: 80, RequirementKeywordID: idPtr(3)}, ErrBadgeKeywordNotFound},
+		{"peso fora da faixa", Badge{RequirementType: BadgeReqKeywordWeight, RequirementValue: 101, RequirementKeywordID: idPtr(1)}, ErrBadgeWeightRange},
+		{"personagem exigido", Badge{RequirementType: BadgeReqCharacterStickers, RequirementValue: 10, RequirementKeywordID: idPtr(1)}, ErrBadgeKeywordCharacter},
+		{"válida", Badge{RequirementType: BadgeReqCharacterStickers, RequirementValue: 10, RequirementKeywordID: idPtr(2)}, nil},
+	}
+	for _, tc := range cases {
+		if err := tc.badge.ValidateKeywordRequirement(keywords); !errors.Is(err, tc.wantErr) {
+			t.Errorf("%s: err = %v, want %v", tc.name, err, tc.wantErr)
+		}
+	}
+}
+
+func TestCountCharacterStickers(t *testing.T) {
+	keywords := map[string]Keyword{
+		"luffy":  {ID: 2, Slug: "luffy", Category: CategoryCharacter},
+		"zoro":   {ID: 4, Slug: "zoro", Category: CategoryCharacter},
+		"crying": {ID: 5, Slug: "crying", Category: CategoryEmotion},
+	}
+	stickers := []Sticker{
+		{IsVisible: true, Keywords: []string{"luffy", "crying"}},
+		{IsVisible: true, Keywords: []string{"luffy", "zoro", "luffy"}},
+		{IsVisible: false, Keywords: []string{"luffy"}},
+		{IsVisible: true, IsDeleted: true, Keywords: []string{"zoro"}},
+		{IsVisible: true, IsModerated: true, Keywords: []string{"zoro"}},
+	}
+	got := CountCharacterStickers(stickers, keywords)
+	if len(got) != 2 || got[2] != 2 || got[4] != 1 {
+		t.Errorf("contagem = %v, want map[2:2 4:1]", got)
+	}
+
+	cases := []struct {
+		name    string
+		sticker Sticker
+		want    bool
+	}{
+		{"com personagem", stickers[0], true},
+		{"privado", stickers[2], false},
+		{"sem personagem", Sticker{IsVisible: true, Keywords: []string{"crying"}}, false},
+	}
+	for _, tc := range cases {
+		if got := StickerBadgesTouched(&tc.sticker, keywords); got != tc.want {
+			t.Errorf("StickerBadgesTouched %s = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestEvaluateKeywordBadges(t *testing.T) {
+	now := time.Now()
+	badges := []Badge{
+		{ID: 10, RequirementType: BadgeReqKeywordWeight, RequirementValue: 80, RequirementKeywordID: idPtr(1)},
+		{ID: 11, RequirementType: BadgeReqCharacterStickers, RequirementValue: 3, RequirementKeywordID: idPtr(2)},
+		{ID: 12, RequirementType: BadgeReqKeywordWeight, RequirementValue: 10, RequirementKeywordID: idPtr(3)},
+		{ID: 13, RequirementType: "packs_created_count", RequirementValue: 1},
+	}
+	keywords := map[int64]Keyword{
+		1: {ID: 1, Category: CategoryWork},
+		2: {ID: 2, Category: CategoryCharacter},
+		3: {ID: 3, IsPending: true},
+	}
+	cases := []struct {
+		name       string
+		weights    []MakerKeyword
+		characters map[int64]uint64
+		owned      []MakerBadge
+		want       []int64
+	}{
+		{"nada alcançado", []MakerKeyword{{IDKeyword: 1, Weight: 79}}, map[int64]uint64{2: 2}, nil, nil},
+		{"peso e personagem", []MakerKeyword{{IDKeyword: 1, Weight: 80}, {IDKeyword: 3, Weight: 90}}, map[int64]uint64{2: 3}, nil, []int64{10, 11}},
+		{"já conquistada", []MakerKeyword{{IDKeyword: 1, Weight: 95}}, nil, []MakerBadge{{IDBadge: 10}}, nil},
+	}
+	for _, tc := range cases {
+		in := KeywordBadgeInput{IDMaker: 7, Badges: badges, Owned: tc.owned, Weights: tc.weights, CharacterStickers: tc.characters, Keywords: keywords}
+		got := EvaluateKeywordBadges(in, now)
+		if len(got) != len(tc.want) {
+			t.Errorf("%s: %+v, want %v", tc.name, got, tc.want)
+			continue
+		}
+		for i, id := range tc.want {
+			if got[i].IDBadge != id || got[i].IDMaker != 7 {
+				t.Errorf("%s: [%d] = %+v, want badge %d", tc.name, i, got[i], id)
+			}
+		}
+	}
+}
+
+func TestKeywordBadgeTriggers(t *testing.T) {
+	weight := &Badge{RequirementType: BadgeReqKeywordWeight, RequirementValue: 80, RequirementKeywordID: idPtr(1)}
+	cases := []struct {
+		name   string
+		before *Badge
+		after  *Badge
+		want   bool
+	}{
+		{"criação", nil, weight, true},
+		{"sem mudança", weight, &Badge{RequirementType: BadgeReqKeywordWeight, RequirementValue: 80, RequirementKeywordID: idPtr(1)}, false},
+		{"valor alterado", weight, &Badge{RequirementType: BadgeReqKeywordWeight, RequirementValue: 70, RequirementKeywordID: idPtr(1)}, true},
+		{"keyword alterada", weight, &Badge{RequirementType: BadgeReqKeywordWeight, RequirementValue: 80, RequirementKeywordID: idPtr(2)}, true},
+		{"badge comum", nil, &Badge{RequirementType: "packs_created_count"}, false},
+	}
+	for _, tc := range cases {
+		if got := KeywordBadgeChanged(tc.before, tc.after); got != tc.want {
+			t.Errorf("%s: %v, want %v", tc.name, got, tc.want)
+		}
+	}
+	if KeywordBadgesTouched([]ExpertiseChange{{IDKeyword: 1, Old: 50, New: 40}}) {
+		t.Error("peso caindo não deveria avaliar badges")
+	}
+	if !KeywordBadgesTouched([]ExpertiseChange{{IDKeyword: 1, Old: 0, New: 5}}) {
+		t.Error("peso novo deveria avaliar badges")
+	}
+}
+
+func TestBackfillKeywordBadge(t *testing.T) {
+	now := time.Now()
+	cases := []struct {
+		name string
+		in   KeywordBadgeBackfillInput
+		want []int64
+	}{
+		{"peso", KeywordBadgeBackfillInput{
+			Badge:   Badge{ID: 10, RequirementType: BadgeReqKeywordWeight, RequirementValue: 80, RequirementKeywordID: idPtr(1)},
+			Keyword: Keyword{ID: 1},
+			Owned:   []MakerBadge{{IDMaker: 8, IDBadge: 10}},
+			Weights: []MakerKeyword{{IDMaker: 7, IDKeyword: 1, Weight: 85}, {IDMaker: 8, IDKeyword: 1, Weight: 90}, {IDMaker: 9, IDKeyword: 1, Weight: 50}},
+		}, []int64{7}},
+		{"personagem", KeywordBadgeBackfillInput{
+			Badge:             Badge{ID: 11, RequirementType: BadgeReqCharacterStickers, RequirementValue: 3, RequirementKeywordID: idPtr(2)},
+			Keyword:           Keyword{ID: 2, Category: CategoryCharacter},
+			CharacterStickers: map[int64]uint64{9: 5, 7: 3, 8: 1},
+		}, []int64{7, 9}},
+		{"keyword divergente", KeywordBadgeBackfillInput{
+			Badge:   Badge{ID: 10, RequirementType: BadgeReqKeywordWeight, RequirementValue: 80, RequirementKeywordID: idPtr(1)},
+			Keyword: Keyword{ID: 2},
+			Weights: []MakerKeyword{{IDMaker: 7, IDKeyword: 2, Weight: 85}},
+		}, nil},
+	}
+	for _, tc := range cases {
+		got := BackfillKeywordBadge(tc.in, now)
+		if len(got) != len(tc.want) {
+			t.Errorf("%s: %+v, want %v", tc.name, got, tc.want)
+			continue
+		}
+		for i, id := range tc.want {
+			if got[i].IDMaker != id || got[i].IDBadge != tc.in.Badge.ID {
+				t.Errorf("%s: [%d] = %+v, want maker %d", tc.name, i, got[i], id)
+			}
+		}
+	}
+}
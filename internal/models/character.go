@@ -0,0 +1,356 @@
+package models
+
+import (
+	"errors"
+	"otamaker-api/internal/constants"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ==========================================================
+// 1. ENTIDADE PRINCIPAL (O Personagem)
+// ==========================================================
+
+var (
+	ErrCharacterWrongAnime = errors.New("personagem não pertence ao anime do sticker")
+	ErrTooManyCharacters   = errors.New("limite de personagens por sticker excedido")
+	ErrCharacterSlugEmpty  = errors.New("não foi possível gerar o slug do personagem: informe 'slug'")
+)
+
+// MaxCharactersPerSticker: Limite de marcações por sticker (evita spam de tags).
+const MaxCharactersPerSticker = 5
+
+// Character: Personagem de um Anime (ex: "Naruto Uzumaki" em "Naruto").
+// Espelha uma Keyword CategoryCharacter (IDKeyword) para a busca por texto continuar funcionando.
+type Character struct {
+	ID int64 `json:"id" db:"id" gorm:"primaryKey"`
+
+	// IDAnime: Obra de origem (obrigatória).
+	IDAnime int64 `json:"id_anime" db:"id_anime" gorm:"index"`
+	// IDKeyword: Keyword CategoryCharacter equivalente (mesmo Slug).
+	IDKeyword int64 `json:"id_keyword" db:"id_keyword" gorm:"uniqueIndex"`
+
+	// Slug Universal (Inglês/Romaji). Ex: "naruto_uzumaki".
+	Slug string `json:"slug" db:"slug" gorm:"uniqueIndex"`
+
+	// INTERNACIONALIZAÇÃO (I18N) - mesmo padrão de Anime.Name.
+	Name map[string]string `json:"name" db:"name" gorm:"serializer:json"`
+
+	// IMAGENS
+	ImageURL        string `json:"image_url" db:"image_url"`
+	ImagePreviewURL string `json:"image_preview_url" db:"image_preview_url"`
+
+	// FLAGS
+	IsVisible   bool `json:"is_visible" db:"is_visible"`
+	IsModerated bool `json:"is_moderated" db:"is_moderated"`
+
+	// DENORMALIZAÇÃO
+	StickersCount uint64 `json:"stickers_count" db:"stickers_count"`
+	PacksCount    uint64 `json:"packs_count" db:"packs_count"` // Packs distintos com stickers do personagem.
+
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// StickerCharacter: Pivot Sticker <-> Personagem.
+type StickerCharacter struct {
+	IDSticker   int64 `json:"id_sticker" db:"id_sticker" gorm:"primaryKey"`
+	IDCharacter int64 `json:"id_character" db:"id_character" gorm:"primaryKey;index"`
+	// AddedKeyword: A marcação criou o vínculo com a keyword espelho (StickerKeyword + slug no cache).
+	// False quando o Maker já tinha digitado a keyword: desmarcar não a remove.
+	AddedKeyword bool      `json:"-" db:"added_keyword"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+}
+
+// StickerTagSet: Estado atual do sticker, carregado pelo Service antes de TagSticker.
+type StickerTagSet struct {
+	Previous []*Character       // Personagens hoje marcados.
+	Links    []StickerCharacter // Pivots atuais do sticker (AddedKeyword).
+	Keywords []StickerKeyword   // Pivots de keyword atuais do sticker.
+}
+
+// StickerTagResult: Linhas a inserir/remover na mesma transação.
+type StickerTagResult struct {
+	Added           []StickerCharacter
+	Removed         []StickerCharacter
+	AddedKeywords   []StickerKeyword
+	RemovedKeywords []StickerKeyword
+}
+
+// ==========================================================
+// 2. INPUTS E DTOs
+// ==========================================================
+
+type CreateCharacterInput struct {
+	IDAnime         int64             `json:"id_anime" binding:"required,gt=0"`
+	Name            map[string]string `json:"name" binding:"required"`
+	Slug            string            `json:"slug" binding:"omitempty,max=64"` // Vazio = gerado do nome padrão.
+	ImageURL        string            `json:"image_url" binding:"required,url"`
+	ImagePreviewURL string            `json:"image_preview_url" binding:"omitempty,url"`
+}
+
+type UpdateCharacterInput struct {
+	Name            map[string]string `json:"name"`
+	ImageURL        *string           `json:"image_url" binding:"omitempty,url"`
+	ImagePreviewURL *string           `json:"image_preview_url" binding:"omitempty,url"`
+	IsVisible       *bool             `json:"is_visible"`
+}
+
+// TagStickerCharactersInput: Substitui a lista de personagens do sticker.
+type TagStickerCharactersInput struct {
+	CharacterIDs []int64 `json:"character_ids" binding:"max=5,dive,gt=0"`
+}
+
+type CharacterResponse struct {
+	ID              int64  `json:"id"`
+	IDAnime         int64  `json:"id_anime"`
+	Slug            string `json:"slug"`
+	Name            string `json:"name"`
+	ImageURL        string `json:"image_url"`
+	ImagePreviewURL string `json:"image_preview_url"`
+	StickersCount   uint64 `json:"stickers_count"`
+	PacksCount      uint64 `json:"packs_count"`
+}
+
+// CharacterPageResponse: Página do personagem (stickers e packs agregados).
+type CharacterPageResponse struct {
+	Character CharacterResponse     `json:"character"`
+	Anime     *AnimeSummaryResponse `json:"anime"`
+	Stickers  []StickerResponse     `json:"stickers"`
+	Packs     []PackResponse        `json:"packs"`
+}
+
+// Mapper (I18N)
+func (c *Character) ToResponse(lang constants.Language) CharacterResponse {
+	return CharacterResponse{
+		ID:              c.ID,
+		IDAnime:         c.IDAnime,
+		Slug:            c.Slug,
+		Name:            lang.Get(c.Name),
+		ImageURL:        c.ImageURL,
+		ImagePreviewURL: c.ImagePreviewURL,
+		StickersCount:   c.StickersCount,
+		PacksCount:      c.PacksCount,
+	}
+}
+
+// ==========================================================
+// 3. LÓGICA (Keyword espelho e Marcação)
+// ==========================================================
+
+// NewCharacter monta o personagem a partir do input.
+// Slug vazio é gerado do nome no idioma padrão; se ele não render um slug (ex: só kanji),
+// tenta os outros idiomas (romaji/inglês). Sem nenhum nome latino, o Admin precisa informar o slug.
+func NewCharacter(in CreateCharacterInput, now time.Time) (Character, error) {
+	slug := Slugify(in.Slug)
+	if slug == "" {
+		slug = Slugify(constants.Default.Get(in.Name))
+	}
+	if slug == "" {
+		langs := make([]string, 0, len(in.Name))
+		for lang := range in.Name {
+			langs = append(langs, lang)
+		}
+		sort.Strings(langs)
+		for _, lang := range langs {
+			if slug = Slugify(in.Name[lang]); slug != "" {
+				break
+			}
+		}
+	}
+	if slug == "" {
+		return Character{}, ErrCharacterSlugEmpty
+	}
+	return Character{
+		IDAnime:         in.IDAnime,
+		Slug:            slug,
+		Name:            in.Name,
+		ImageURL:        in.ImageURL,
+		ImagePreviewURL: in.ImagePreviewURL,
+		IsVisible:       true,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}, nil
+}
+
+// ToKeyword gera (ou atualiza) a Keyword CategoryCharacter espelho.
+// Os nomes traduzidos são copiados para Keyword.Name e também viram Aliases, para "Naruto" em qualquer idioma cair no mesmo slug.
+func (c *Character) ToKeyword(existing *Keyword, now time.Time) Keyword {
+	k := Keyword{CreatedAt: now}
+	if existing != nil {
+		k = *existing
+	}
+	k.Slug = c.Slug
+	if k.Name == nil {
+		k.Name = map[string]string{}
+	}
+	for lang, name := range c.Name {
+		k.Name[lang] = name
+	}
+	k.Category = CategoryCharacter
+	k.UpdatedAt = now
+
+	for _, name := range c.Name {
+		alias := strings.ToLower(strings.TrimSpace(name))
+		if alias != "" && !containsString(k.Aliases, alias) {
+			k.Aliases = append(k.Aliases, alias)
+		}
+	}
+	return k
+}
+
+// TagSticker substitui a lista de personagens do sticker pela lista pedida.
+// Marcar vincula a keyword espelho pelo mesmo caminho das keywords digitadas: StickerKeyword,
+// UsageCount (via resolver, que deve conter as keywords espelho) e o slug no cache 'Sticker.Keywords'.
+// Se o sticker já tinha a keyword, a marcação não cria nada e desmarcar não a remove.
+// Regra 6 do Anime: o personagem precisa ser do mesmo anime do sticker (quando o sticker tem anime).
+// chars: lista final pedida. set: personagens, pivots e keywords atuais do sticker.
+// Tudo é validado antes de alterar o sticker ou os contadores: em erro, nada muda.
+func TagSticker(s *Sticker, chars []*Character, set StickerTagSet, r *KeywordResolver, now time.Time) (StickerTagResult, error) {
+	var result StickerTagResult
+	wanted := make(map[int64]*Character, len(chars))
+	order := make([]*Character, 0, len(chars))
+	for _, c := range chars {
+		if s.IDAnime != nil && *s.IDAnime != c.IDAnime {
+			return result, ErrCharacterWrongAnime
+		}
+		if _, dup := wanted[c.ID]; dup {
+			continue
+		}
+		wanted[c.ID] = c
+		order = append(order, c)
+	}
+	if len(order) > MaxCharactersPerSticker {
+		return result, ErrTooManyCharacters
+	}
+
+	hasKeyword := make(map[int64]bool, len(set.Keywords))
+	for _, sk := range set.Keywords {
+		hasKeyword[sk.IDKeyword] = true
+	}
+	addedByTag := make(map[int64]bool, len(set.Links))
+	for _, l := range set.Links {
+		addedByTag[l.IDCharacter] = l.AddedKeyword
+	}
+
+	had := make(map[int64]bool, len(set.Previous))
+	for _, c := range set.Previous {
+		had[c.ID] = true
+		if _, keep := wanted[c.ID]; keep {
+			continue
+		}
+		result.Removed = append(result.Removed, StickerCharacter{IDSticker: s.ID, IDCharacter: c.ID})
+		decrement(&c.StickersCount)
+		if !addedByTag[c.ID] {
+			continue
+		}
+		s.Keywords = removeString(s.Keywords, c.Slug)
+		if hasKeyword[c.IDKeyword] {
+			result.RemovedKeywords = append(result.RemovedKeywords, StickerKeyword{IDSticker: s.ID, IDKeyword: c.IDKeyword})
+			r.addUsage(c.IDKeyword, -1)
+			hasKeyword[c.IDKeyword] = false
+		}
+	}
+	for _, c := range order {
+		if had[c.ID] {
+			continue
+		}
+		link := StickerCharacter{IDSticker: s.ID, IDCharacter: c.ID, CreatedAt: now}
+		if !hasKeyword[c.IDKeyword] && !containsString(s.Keywords, c.Slug) {
+			link.AddedKeyword = true
+			s.Keywords = append(s.Keywords, c.Slug)
+			if c.IDKeyword != 0 {
+				result.AddedKeywords = append(result.AddedKeywords, StickerKeyword{IDSticker: s.ID, IDKeyword: c.IDKeyword, CreatedAt: now})
+				r.addUsage(c.IDKeyword, 1)
+				hasKeyword[c.IDKeyword] = true
+			}
+		}
+		result.Added = append(result.Added, link)
+		c.StickersCount++
+	}
+	if len(result.Added)+len(result.Removed) > 0 {
+		s.UpdatedAt = now
+	}
+	return result, nil
+}
+
+// RecomputeCounters recalcula StickersCount/PacksCount pelas linhas reais (Job).
+// stickers: stickers marcados com o personagem. links: PackSticker desses stickers.
+// packs: packs referenciados (só contam os publicados e não deletados).
+func (c *Character) RecomputeCounters(stickers []Sticker, links []PackSticker, packs map[int64]Pack) {
+	visible := make(map[int64]bool, len(stickers))
+	for i := range stickers {
+		if stickers[i].IsVisible && !stickers[i].IsDeleted && !stickers[i].IsModerated {
+			visible[stickers[i].ID] = true
+		}
+	}
+
+	distinct := map[int64]bool{}
+	for _, l := range links {
+		p, ok := packs[l.IDPack]
+		if !ok || !visible[l.IDSticker] || p.IsDeleted || !p.IsPublished() {
+			continue
+		}
+		distinct[l.IDPack] = true
+	}
+
+	c.StickersCount = uint64(len(visible))
+	c.PacksCount = uint64(len(distinct))
+}
+
+// ==========================================================
+// 4. PÁGINA DO PERSONAGEM
+// ==========================================================
+
+// BuildPage monta a página do personagem para quem está vendo.
+// stickers: stickers marcados com o personagem. packs/responses: packs que os contêm (responses via BuildPackResponses).
+// Só entram stickers visíveis e packs publicados (Regra 6); depois valem o teto de classificação e o spoiler do usuário.
+// Anime oculto, moderado ou acima do teto não é embutido.
+func (c *Character) BuildPage(anime *Anime, stickers []Sticker, packs []Pack, responses []PackResponse, prefs SpoilerPrefs, ceiling ContentRating, lang constants.Language) CharacterPageResponse {
+	page := CharacterPageResponse{Character: c.ToResponse(lang)}
+	if anime != nil && anime.IsVisible && !anime.IsModerated && ceiling.Allows(anime.ContentRating) {
+		summary := anime.ToSummaryResponse(lang)
+		page.Anime = &summary
+	}
+
+	live := make([]Sticker, 0, len(stickers))
+	for i := range stickers {
+		if stickers[i].IsVisible && !stickers[i].IsDeleted && !stickers[i].IsModerated {
+			live = append(live, stickers[i])
+		}
+	}
+	page.Stickers = StickersForViewer(live, prefs, ceiling)
+
+	published := make([]Pack, 0, len(packs))
+	for i := range packs {
+		if !packs[i].IsDeleted && packs[i].IsPublished() {
+			published = append(published, packs[i])
+		}
+	}
+	page.Packs = PacksForViewer(published, responses, prefs, ceiling)
+	return page
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+REGRAS DO PERSONAGEM:
+1.  Origem: Todo personagem pertence a um Anime (IDAnime). Nome segue o padrão I18N de Anime.Name.
+2.  Espelho: Cada personagem tem uma Keyword CategoryCharacter com o MESMO slug. Nomes traduzidos viram Aliases.
+3.  Busca: Marcar um sticker com um personagem adiciona o slug em 'Sticker.Keywords', então a busca textual existente já encontra.
+4.  Consistência: Só é possível marcar personagens do mesmo anime do sticker (Regra 6 do Anime).
+5.  Limite: No máximo 5 personagens por sticker. Acima disso a marcação é recusada (nada é truncado).
+6.  Página: A página do personagem agrega stickers visíveis e os packs publicados que os contêm (BuildPage),
+    respeitando o teto de classificação e o spoiler de quem vê. Os contadores só contam stickers visíveis.
+7.  Marcação: A lista enviada substitui a atual. Marcar cria StickerKeyword da keyword espelho e soma UsageCount, como uma keyword digitada.
+    Desmarcar decrementa o contador e só remove pivot/slug se foi a marcação que os criou (StickerCharacter.AddedKeyword).
+8.  Slug: Gerado do nome padrão ou de outro idioma com letras latinas. Sem nenhum, o Admin deve informar o slug.
+*/
@@ -0,0 +1,218 @@
+package models
+
+import (
+	"errors"
+	"otamaker-api/internal/constants"
+	"testing"
+	"time"
+)
+
+func TestTagSticker(t *testing.T) {
+	now := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	naruto := int64(10)
+	newChars := func() (*Character, *Character) {
+		return &Character{ID: 1, IDAnime: naruto, IDKeyword: 100, Slug: "naruto_uzumaki", StickersCount: 3},
+			&Character{ID: 2, IDAnime: naruto, IDKeyword: 200, Slug: "sasuke_uchiha", StickersCount: 1}
+	}
+	cases := []struct {
+		name         string
+		keywords     []string
+		current      []StickerKeyword
+		links        []StickerCharacter
+		previous     func(a, b *Character) []*Character
+		chars        func(a, b *Character) []*Character
+		wantKeywords []string
+		wantAddedKW  int
+		wantRemoveKW int
+		wantUsage    map[int64]uint64
+		wantAdded    bool // AddedKeyword do primeiro pivot criado.
+	}{
+		{
+			name:         "marcar cria pivot, slug e uso",
+			keywords:     []string{"crying"},
+			previous:     func(a, b *Character) []*Character { return nil },
+			chars:        func(a, b *Character) []*Character { return []*Character{a} },
+			wantKeywords: []string{"crying", "naruto_uzumaki"},
+			wantAddedKW:  1,
+			wantUsage:    map[int64]uint64{100: 6, 200: 2},
+			wantAdded:    true,
+		},
+		{
+			name:         "keyword já digitada não é duplicada",
+			keywords:     []string{"naruto_uzumaki"},
+			current:      []StickerKeyword{{IDKeyword: 100}},
+			previous:     func(a, b *Character) []*Character { return nil },
+			chars:        func(a, b *Character) []*Character { return []*Character{a} },
+			wantKeywords: []string{"naruto_uzumaki"},
+			wantUsage:    map[int64]uint64{100: 5, 200: 2},
+		},
+		{
+			name:         "desmarcar remove o que a marcação criou",
+			keywords:     []string{"naruto_uzumaki", "sasuke_uchiha"},
+			current:      []StickerKeyword{{IDKeyword: 100}, {IDKeyword: 200}},
+			links:        []StickerCharacter{{IDCharacter: 1, AddedKeyword: true}, {IDCharacter: 2, AddedKeyword: true}},
+			previous:     func(a, b *Character) []*Character { return []*Character{a, b} },
+			chars:        func(a, b *Character) []*Character { return []*Character{b} },
+			wantKeywords: []string{"sasuke_uchiha"},
+			wantRemoveKW: 1,
+			wantUsage:    map[int64]uint64{100: 4, 200: 2},
+		},
+		{
+			name:         "desmarcar mantém a keyword digitada pelo Maker",
+			keywords:     []string{"naruto_uzumaki"},
+			current:      []StickerKeyword{{IDKeyword: 100}},
+			links:        []StickerCharacter{{IDCharacter: 1, AddedKeyword: false}},
+			previous:     func(a, b *Character) []*Character { return []*Character{a} },
+			chars:        func(a, b *Character) []*Character { return nil },
+			wantKeywords: []string{"naruto_uzumaki"},
+			wantUsage:    map[int64]uint64{100: 5, 200: 2},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			a, b := newChars()
+			keywords := []Keyword{{ID: 100, Slug: "naruto_uzumaki", UsageCount: 5}, {ID: 200, Slug: "sasuke_uchiha", UsageCount: 2}}
+			r := NewKeywordResolver(keywords)
+			s := &Sticker{ID: 7, IDAnime: &naruto, Keywords: tc.keywords}
+			set := StickerTagSet{Previous: tc.previous(a, b), Links: tc.links, Keywords: tc.current}
+
+			result, err := TagSticker(s, tc.chars(a, b), set, r, now)
+			if err != nil {
+				t.Fatalf("err = %v", err)
+			}
+			if len(result.AddedKeywords) != tc.wantAddedKW || len(result.RemovedKeywords) != tc.wantRemoveKW {
+				t.Errorf("pivots de keyword = +%d/-%d", len(result.AddedKeywords), len(result.RemovedKeywords))
+			}
+			if len(s.Keywords) != len(tc.wantKeywords) {
+				t.Fatalf("Keywords = %v, want %v", s.Keywords, tc.wantKeywords)
+			}
+			for i := range tc.wantKeywords {
+				if s.Keywords[i] != tc.wantKeywords[i] {
+					t.Errorf("Keywords = %v, want %v", s.Keywords, tc.wantKeywords)
+				}
+			}
+			for _, k := range keywords {
+				if k.UsageCount != tc.wantUsage[k.ID] {
+					t.Errorf("UsageCount[%d] = %d, want %d", k.ID, k.UsageCount, tc.wantUsage[k.ID])
+				}
+			}
+			if len(result.Added) > 0 && result.Added[0].AddedKeyword != tc.wantAdded {
+				t.Errorf("AddedKeyword = %v, want %v", result.Added[0].AddedKeyword, tc.wantAdded)
+			}
+		})
+	}
+}
+
+func TestTagStickerRejects(t *testing.T) {
+	naruto, other := int64(10), int64(11)
+	chars := make([]*Character, 0, MaxCharactersPerSticker+1)
+	for i := 1; i <= MaxCharactersPerSticker+1; i++ {
+		chars = append(chars, &Character{ID: int64(i), IDAnime: naruto, Slug: "c"})
+	}
+	cases := []struct {
+		name    string
+		chars   []*Character
+		wantErr error
+	}{
+		{"anime diferente", []*Character{{ID: 1, IDAnime: other, Slug: "x"}}, ErrCharacterWrongAnime},
+		{"acima do limite", chars, ErrTooManyCharacters},
+	}
+	for _, tc := range cases {
+		s := &Sticker{IDAnime: &naruto, Keywords: []string{"crying"}}
+		_, err := TagSticker(s, tc.chars, StickerTagSet{}, NewKeywordResolver(nil), time.Now())
+		if !errors.Is(err, tc.wantErr) {
+			t.Errorf("%s: err = %v, want %v", tc.name, err, tc.wantErr)
+		}
+		if len(s.Keywords) != 1 || !s.UpdatedAt.IsZero() {
+			t.Errorf("%s: sticker alterado em erro: %+v", tc.name, s)
+		}
+	}
+}
+
+func TestRemoveStringCopies(t *testing.T) {
+	list := []string{"a", "b", "c"}
+	out := removeString(list, "a")
+	if len(out) != 2 || list[0] != "a" || list[1] != "b" || list[2] != "c" {
+		t.Errorf("removeString alterou a lista original: %v -> %v", list, out)
+	}
+}
+
+func TestCharacterRecomputeCounters(t *testing.T) {
+	stickers := []Sticker{
+		{ID: 1, IsVisible: true},
+		{ID: 2, IsVisible: true},
+		{ID: 3, IsVisible: false},
+		{ID: 4, IsVisible: true, IsDeleted: true},
+		{ID: 5, IsVisible: true, IsModerated: true},
+	}
+	links := []PackSticker{{IDPack: 10, IDSticker: 1}, {IDPack: 10, IDSticker: 2}, {IDPack: 11, IDSticker: 3}, {IDPack: 12, IDSticker: 1}, {IDPack: 13, IDSticker: 2}}
+	packs := map[int64]Pack{
+		10: {ID: 10, Status: PackPublished},
+		11: {ID: 11, Status: PackPublished}, // Só tem o sticker oculto.
+		12: {ID: 12, Status: PackDraft},
+		13: {ID: 13, Status: PackPublished, IsDeleted: true},
+	}
+	c := &Character{StickersCount: 99, PacksCount: 99}
+	c.RecomputeCounters(stickers, links, packs)
+	if c.StickersCount != 2 || c.PacksCount != 1 {
+		t.Errorf("contadores = %d/%d, want 2/1", c.StickersCount, c.PacksCount)
+	}
+}
+
+func TestCharacterBuildPage(t *testing.T) {
+	animeID, episode := int64(10), int16(20)
+	anime := &Anime{ID: animeID, IsVisible: true, ContentRating: RatingSuggestive, Name: map[string]string{"pt_br": "Naruto"}}
+	c := &Character{ID: 1, IDAnime: animeID, Slug: "naruto_uzumaki", Name: map[string]string{"pt_br": "Naruto Uzumaki"}}
+	stickers := []Sticker{
+		{ID: 1, IsVisible: true},
+		{ID: 2, IsVisible: true, ContentRating: RatingAdult},
+		{ID: 3, IsVisible: true, IsSpoiler: true, SpoilerAnimeID: &animeID, SpoilerEpisode: &episode},
+		{ID: 4, IsVisible: false},
+	}
+	packs := []Pack{
+		{ID: 20, Status: PackPublished},
+		{ID: 21, Status: PackDraft},
+		{ID: 22, Status: PackPublished, ContentRating: RatingAdult},
+		{ID: 23, Status: PackPublished, IsSpoiler: true, SpoilerAnimeID: &animeID},
+	}
+	responses := []PackResponse{{ID: 20}, {ID: 21}, {ID: 22}, {ID: 23}}
+	cases := []struct {
+		name         string
+		prefs        SpoilerPrefs
+		ceiling      ContentRating
+		wantAnime    bool
+		wantStickers []int64
+		wantPacks    []int64
+	}{
+		{"anônimo", NewSpoilerPrefs(nil, nil), RatingSafe, false, []int64{1, 3}, []int64{20, 23}},
+		{"esconde spoiler", NewSpoilerPrefs(&MakerSettings{SpoilerMode: SpoilerModeHide}, nil), RatingSuggestive, true, []int64{1}, []int64{20}},
+		{"adulto que terminou", NewSpoilerPrefs(&MakerSettings{SpoilerMode: SpoilerModeHide}, []MakerAnimeSpoiler{{IDAnime: animeID, HasFinished: true}}), RatingAdult, true, []int64{1, 2, 3}, []int64{20, 22, 23}},
+	}
+	for _, tc := range cases {
+		page := c.BuildPage(anime, stickers, packs, responses, tc.prefs, tc.ceiling, constants.PT_BR)
+		if (page.Anime != nil) != tc.wantAnime {
+			t.Errorf("%s: anime = %v, want %v", tc.name, page.Anime, tc.wantAnime)
+		}
+		if page.Character.Name != "Naruto Uzumaki" {
+			t.Errorf("%s: personagem = %+v", tc.name, page.Character)
+		}
+		if len(page.Stickers) != len(tc.wantStickers) {
+			t.Errorf("%s: stickers = %d, want %v", tc.name, len(page.Stickers), tc.wantStickers)
+		} else {
+			for i, id := range tc.wantStickers {
+				if page.Stickers[i].ID != id {
+					t.Errorf("%s: stickers[%d] = %d, want %d", tc.name, i, page.Stickers[i].ID, id)
+				}
+			}
+		}
+		if len(page.Packs) != len(tc.wantPacks) {
+			t.Errorf("%s: packs = %d, want %v", tc.name, len(page.Packs), tc.wantPacks)
+			continue
+		}
+		for i, id := range tc.wantPacks {
+			if page.Packs[i].ID != id {
+				t.Errorf("%s: packs[%d] = %d, want %d", tc.name, i, page.Packs[i].ID, id)
+			}
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package models
+
+import (
+	"errors"
+	"otamaker-api/internal/constants"
+)
+
+// ==========================================================
+// 1. CLASSIFICAÇÃO DE CONTEÚDO (Safe / Suggestive / Adult)
+// ==========================================================
+
+// ContentRating: Classificação indicativa de Stickers, Packs e Animes.
+// Vazio (registros antigos) é tratado como Safe.
+type ContentRating string
+
+const (
+	RatingSafe       ContentRating = "safe"       // Livre.
+	RatingSuggestive ContentRating = "suggestive" // Insinuação, fanservice leve (ex: Ecchi).
+	RatingAdult      ContentRating = "adult"      // Somente para quem ativou 'ShowAdultContent'.
+)
+
+// Ordem de severidade. Usada para escalonar (o mais adulto vence) e para filtrar.
+var ratingLevel = map[ContentRating]int8{
+	"":               0,
+	RatingSafe:       0,
+	RatingSuggestive: 1,
+	RatingAdult:      2,
+}
+
+// Gêneros que elevam o piso da classificação do Anime.
+var genreRatingFloor = map[constants.Genre]ContentRating{
+	constants.Ecchi: RatingSuggestive,
+}
+
+var ErrContentRestricted = errors.New("conteúdo indisponível para a classificação permitida ao usuário")
+
+// IsValid verifica se a classificação existe (vazio não é válido como entrada).
+func (r ContentRating) IsValid() bool {
+	return r == RatingSafe || r == RatingSuggestive || r == RatingAdult
+}
+
+// Level: 0 = Safe, 1 = Suggestive, 2 = Adult.
+func (r ContentRating) Level() int8 {
+	return ratingLevel[r]
+}
+
+// Normalize converte vazio/desconhecido em Safe.
+func (r ContentRating) Normalize() ContentRating {
+	if !r.IsValid() {
+		return RatingSafe
+	}
+	return r
+}
+
+// MaxRating devolve a classificação mais severa entre as informadas.
+func MaxRating(ratings ...ContentRating) ContentRating {
+	top := RatingSafe
+	for _, r := range ratings {
+		if r.Level() > top.Level() {
+			top = r
+		}
+	}
+	return top
+}
+
+// ==========================================================
+// 2. ESCALONAMENTO (Filhos -> Pais)
+// ==========================================================
+
+// SetRating grava a classificação declarada do sticker (Maker ou Moderação).
+// O Service deve recalcular os packs que contêm o sticker (Pack.RecomputeRating).
+func (s *Sticker) SetRating(r ContentRating) {
+	s.ContentRating = r.Normalize()
+}
+
+// RecomputeRating: O pack é no mínimo tão adulto quanto o seu sticker mais adulto.
+// stickers: stickers atuais do pack. Remover o sticker adulto rebaixa o pack de volta ao declarado.
+func (p *Pack) RecomputeRating(stickers []Sticker) {
+	rating := p.DeclaredRating.Normalize()
+	for i := range stickers {
+		rating = MaxRating(rating, stickers[i].ContentRating)
+	}
+	p.ContentRating = rating
+}
+
+// RecomputeRating: Anime = maior entre o declarado pelo Admin e o piso dos gêneros (Ecchi = Suggestive).
+// Packs de fãs NÃO escalam o anime (um pack adulto não esconde a obra inteira).
+func (a *Anime) RecomputeRating() {
+	rating := a.DeclaredRating.Normalize()
+	for _, g := range a.Genres {
+		rating = MaxRating(rating, genreRatingFloor[g])
+	}
+	a.ContentRating = rating
+}
+
+// ==========================================================
+// 3. FILTRO POR USUÁRIO
+// ==========================================================
+
+// ViewerMaxRating: Teto de classificação para quem está vendo.
+// settings nil = visitante anônimo (só Safe). Logado sem 'ShowAdultContent' vê até Suggestive.
+func ViewerMaxRating(settings *MakerSettings) ContentRating {
+	if settings == nil {
+		return RatingSafe
+	}
+	if settings.ShowAdultContent {
+		return RatingAdult
+	}
+	return RatingSuggestive
+}
+
+// Allows verifica se o conteúdo cabe no teto do usuário.
+func (r ContentRating) Allows(other ContentRating) bool {
+	return other.Level() <= r.Level()
+}
+
+// CheckExport: Download/Exportação de pack para o WhatsApp respeita o mesmo teto das listagens.
+func CheckExport(p *Pack, ceiling ContentRating) error {
+	if !ceiling.Allows(p.ContentRating) {
+		return ErrContentRestricted
+	}
+	return nil
+}
+
+// FilterStickersByRating remove os stickers acima do teto (listas, busca e páginas de personagem).
+func FilterStickersByRating(stickers []Sticker, ceiling ContentRating) []Sticker {
+	out := make([]Sticker, 0, len(stickers))
+	for i := range stickers {
+		if ceiling.Allows(stickers[i].ContentRating) {
+			out = append(out, stickers[i])
+		}
+	}
+	return out
+}
+
+// FilterAnimesByRating remove os animes acima do teto.
+func FilterAnimesByRating(animes []Anime, ceiling ContentRating) []Anime {
+	out := make([]Anime, 0, len(animes))
+	for i := range animes {
+		if ceiling.Allows(animes[i].ContentRating) {
+			out = append(out, animes[i])
+		}
+	}
+	return out
+}
+
+/*
+REGRAS DE CLASSIFICAÇÃO DE CONTEÚDO:
+1.  Níveis: Safe < Suggestive < Adult. Registros sem classificação contam como Safe.
+2.  Sticker: Classificado pelo Maker no envio. A moderação pode elevar (ActionEditForce).
+3.  Pack: 'DeclaredRating' é o que o Maker informou; 'ContentRating' é o efetivo, no mínimo o do sticker mais adulto.
+    Toda alteração de stickers (ou da classificação de um sticker) recalcula os packs afetados.
+4.  Anime: 'ContentRating' é o maior entre o declarado pelo Admin e o piso dos gêneros (Ecchi = Suggestive).
+5.  Teto do Usuário: Anônimo = Safe. Logado = Suggestive. 'ShowAdultContent' = Adult.
+6.  Cobertura: Toda listagem pública aplica o teto do usuário: feeds (StickersForViewer/PacksForViewer), temporada (BuildCurrentSeason),
+    catálogo (AnimeCatalogFilter.MaxRating), Home (FilterHomeFeed), obras relacionadas (BuildRelatedWorks), busca e autocomplete.
+7.  Exportação: Download passa por ValidateDownload (CheckExport + regras do WhatsApp). Publicar recalcula o ContentRating do pack.
+*/
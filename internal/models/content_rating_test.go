@@ -0,0 +1,115 @@
+package models
+
+import (
+	"errors"
+	"otamaker-api/internal/constants"
+	"testing"
+)
+
+func TestContentRatingLevels(t *testing.T) {
+	cases := []struct {
+		name      string
+		ratings   []ContentRating
+		wantMax   ContentRating
+		normalize ContentRating
+	}{
+		{"vazio é safe", []ContentRating{""}, RatingSafe, RatingSafe},
+		{"desconhecido é safe", []ContentRating{"gore"}, RatingSafe, RatingSafe},
+		{"mais adulto vence", []ContentRating{RatingSuggestive, RatingAdult, RatingSafe}, RatingAdult, RatingSuggestive},
+		{"sem entrada", nil, RatingSafe, RatingSafe},
+	}
+	for _, tc := range cases {
+		if got := MaxRating(tc.ratings...); got != tc.wantMax {
+			t.Errorf("%s: MaxRating = %q, want %q", tc.name, got, tc.wantMax)
+		}
+		if len(tc.ratings) > 0 {
+			if got := tc.ratings[0].Normalize(); got != tc.normalize {
+				t.Errorf("%s: Normalize = %q, want %q", tc.name, got, tc.normalize)
+			}
+		}
+	}
+}
+
+func TestPackRecomputeRating(t *testing.T) {
+	cases := []struct {
+		name     string
+		declared ContentRating
+		stickers []Sticker
+		want     ContentRating
+	}{
+		{"sem stickers", "", nil, RatingSafe},
+		{"sticker adulto eleva", RatingSafe, []Sticker{{ContentRating: RatingSafe}, {ContentRating: RatingAdult}}, RatingAdult},
+		{"declarado é o piso", RatingSuggestive, []Sticker{{ContentRating: RatingSafe}}, RatingSuggestive},
+		{"remover o adulto rebaixa", RatingSafe, []Sticker{{ContentRating: RatingSuggestive}}, RatingSuggestive},
+	}
+	for _, tc := range cases {
+		p := &Pack{DeclaredRating: tc.declared, ContentRating: RatingAdult}
+		p.RecomputeRating(tc.stickers)
+		if p.ContentRating != tc.want {
+			t.Errorf("%s: ContentRating = %q, want %q", tc.name, p.ContentRating, tc.want)
+		}
+	}
+}
+
+func TestAnimeRecomputeRating(t *testing.T) {
+	cases := []struct {
+		name     string
+		declared ContentRating
+		genres   []constants.Genre
+		want     ContentRating
+	}{
+		{"ecchi eleva o piso", RatingSafe, []constants.Genre{constants.Acao, constants.Ecchi}, RatingSuggestive},
+		{"declarado adulto vence", RatingAdult, []constants.Genre{constants.Ecchi}, RatingAdult},
+		{"sem piso", "", []constants.Genre{constants.Drama}, RatingSafe},
+	}
+	for _, tc := range cases {
+		a := &Anime{DeclaredRating: tc.declared, Genres: tc.genres}
+		a.RecomputeRating()
+		if a.ContentRating != tc.want {
+			t.Errorf("%s: ContentRating = %q, want %q", tc.name, a.ContentRating, tc.want)
+		}
+	}
+}
+
+func TestViewerMaxRating(t *testing.T) {
+	cases := []struct {
+		name     string
+		settings *MakerSettings
+		want     ContentRating
+	}{
+		{"anônimo", nil, RatingSafe},
+		{"logado", &MakerSettings{}, RatingSuggestive},
+		{"adulto", &MakerSettings{ShowAdultContent: true}, RatingAdult},
+	}
+	for _, tc := range cases {
+		ceiling := ViewerMaxRating(tc.settings)
+		if ceiling != tc.want {
+			t.Errorf("%s: teto = %q, want %q", tc.name, ceiling, tc.want)
+		}
+		err := CheckExport(&Pack{ContentRating: RatingAdult}, ceiling)
+		if wantErr := tc.want != RatingAdult; errors.Is(err, ErrContentRestricted) != wantErr {
+			t.Errorf("%s: CheckExport = %v", tc.name, err)
+		}
+	}
+}
+
+func TestFilterByRating(t *testing.T) {
+	stickers := []Sticker{{ID: 1}, {ID: 2, ContentRating: RatingSuggestive}, {ID: 3, ContentRating: RatingAdult}}
+	animes := []Anime{{ID: 1}, {ID: 2, ContentRating: RatingSuggestive}, {ID: 3, ContentRating: RatingAdult}}
+	cases := []struct {
+		ceiling ContentRating
+		want    int
+	}{
+		{RatingSafe, 1},
+		{RatingSuggestive, 2},
+		{RatingAdult, 3},
+	}
+	for _, tc := range cases {
+		if got := FilterStickersByRating(stickers, tc.ceiling); len(got) != tc.want {
+			t.Errorf("stickers %q: %d, want %d", tc.ceiling, len(got), tc.want)
+		}
+		if got := FilterAnimesByRating(animes, tc.ceiling); len(got) != tc.want {
+			t.Errorf("animes %q: %d, want %d", tc.ceiling, len(got), tc.want)
+		}
+	}
+}
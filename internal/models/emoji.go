@@ -0,0 +1,194 @@
+package models
+
+import "strings"
+
+// ==========================================================
+// 1. NORMALIZAÇÃO DE EMOJIS
+// ==========================================================
+
+const (
+	zwj            = '\u200d' // Zero Width Joiner: une emojis em um só ("👨‍👩‍👧").
+	variationText  = '\ufe0e' // Força versão texto.
+	variationEmoji = '\ufe0f' // Força versão colorida.
+	keycap         = '\u20e3' // "1️⃣"
+)
+
+func isSkinTone(r rune) bool { return r >= 0x1F3FB && r <= 0x1F3FF }
+
+func isVariation(r rune) bool { return r == variationText || r == variationEmoji }
+
+func isRegionalIndicator(r rune) bool { return r >= 0x1F1E6 && r <= 0x1F1FF }
+
+func isTag(r rune) bool { return r >= 0xE0020 && r <= 0xE007F } // Bandeiras de subdivisão (🏴󠁧󠁢󠁳󠁣󠁴󠁿).
+
+// SplitEmojis separa um texto em emojis individuais (cada sequência ZWJ, bandeira ou keycap é um só).
+// Caracteres comuns (letras, espaços) são ignorados.
+func SplitEmojis(text string) []string {
+	var out []string
+	var cur []rune
+	flush := func() {
+		if len(cur) > 0 {
+			out = append(out, string(cur))
+			cur = cur[:0]
+		}
+	}
+
+	runes := []rune(text)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == zwj:
+			// Junta com o próximo emoji da sequência.
+			if len(cur) > 0 && i+1 < len(runes) {
+				cur = append(cur, r, runes[i+1])
+				i++
+			}
+		case isSkinTone(r), isVariation(r), r == keycap, isTag(r):
+			if len(cur) > 0 {
+				cur = append(cur, r)
+			}
+		case isRegionalIndicator(r):
+			// Bandeiras: pares de indicadores regionais.
+			flush()
+			cur = append(cur, r)
+			if i+1 < len(runes) && isRegionalIndicator(runes[i+1]) {
+				cur = append(cur, runes[i+1])
+				i++
+			}
+			flush()
+		case isEmojiBase(r, runes, i):
+			flush()
+			cur = append(cur, r)
+		default:
+			flush()
+		}
+	}
+	flush()
+
+	// Sequências só com modificadores soltos não contam.
+	valid := out[:0]
+	for _, e := range out {
+		if NormalizeEmoji(e) != "" {
+			valid = append(valid, e)
+		}
+	}
+	return valid
+}
+
+// isEmojiBase: Faixas de pictogramas + dígitos/#/* seguidos de keycap.
+func isEmojiBase(r rune, runes []rune, i int) bool {
+	if (r >= '0' && r <= '9') || r == '#' || r == '*' {
+		for j := i + 1; j < len(runes) && j <= i+2; j++ {
+			if runes[j] == keycap {
+				return true
+			}
+		}
+		return false
+	}
+	return (r >= 0x1F000 && r <= 0x1FAFF) || (r >= 0x2600 && r <= 0x27BF) ||
+		(r >= 0x2190 && r <= 0x21FF) || (r >= 0x2B00 && r <= 0x2BFF) ||
+		r == 0x00A9 || r == 0x00AE || r == 0x203C || r == 0x2049 || r == 0x2122 || r == 0x2139 ||
+		(r >= 0x2300 && r <= 0x23FF) || r == 0x3030 || r == 0x303D || r == 0x3297 || r == 0x3299
+}
+
+// NormalizeEmoji: Forma canônica para comparação.
+// Remove tons de pele e seletores de variação: "👍🏽" = "👍" e "❤️" = "❤".
+// Sequências ZWJ continuam unidas ("👩‍💻"), apenas sem os modificadores.
+func NormalizeEmoji(e string) string {
+	var sb strings.Builder
+	sb.Grow(len(e))
+	for _, r := range strings.TrimSpace(e) {
+		if isSkinTone(r) || isVariation(r) {
+			continue
+		}
+		sb.WriteRune(r)
+	}
+	return strings.Trim(sb.String(), string(zwj))
+}
+
+// EmojiBase: Primeiro componente de uma sequência ZWJ ("👩‍💻" -> "👩"). Usado como correspondência parcial.
+func EmojiBase(e string) string {
+	n := NormalizeEmoji(e)
+	if i := strings.IndexRune(n, zwj); i > 0 {
+		return n[:i]
+	}
+	return n
+}
+
+// NormalizeEmojis: Chaves de índice e de consulta (normalizadas e sem repetição, na ordem do Maker).
+// 'Sticker.Emojis' NÃO passa por aqui: é salvo como o Maker enviou (o WhatsApp exibe o emoji original).
+func NormalizeEmojis(list []string) []string {
+	out := make([]string, 0, len(list))
+	seen := map[string]bool{}
+	for _, raw := range list {
+		for _, e := range SplitEmojis(raw) {
+			n := NormalizeEmoji(e)
+			if !seen[n] {
+				seen[n] = true
+				out = append(out, n)
+			}
+		}
+	}
+	return out
+}
+
+// ==========================================================
+// 2. EMOJI -> EMOÇÃO (CategoryEmotion)
+// ==========================================================
+
+// emojiEmotions: Mapa padrão. Curadores podem complementar adicionando o emoji
+// aos Aliases de uma Keyword CategoryEmotion (o KeywordResolver encontra).
+var emojiEmotions = map[string][]string{
+	"😀": {"happy"}, "😃": {"happy"}, "😄": {"happy"}, "😁": {"happy"}, "😊": {"happy"}, "🙂": {"happy"},
+	"😂": {"laughing"}, "🤣": {"laughing"}, "😆": {"laughing"},
+	"😢": {"sad"}, "😞": {"sad"}, "😔": {"sad"}, "🥺": {"sad"}, "☹": {"sad"}, "🙁": {"sad"},
+	"😭": {"crying", "sad"},
+	"😡": {"angry"}, "😠": {"angry"}, "🤬": {"angry"}, "💢": {"angry"},
+	"😱": {"scared"}, "😨": {"scared"}, "😰": {"scared"},
+	"😮": {"surprised"}, "😲": {"surprised"}, "😯": {"surprised"}, "🤯": {"surprised"},
+	"😍": {"love"}, "🥰": {"love"}, "😘": {"love"}, "❤": {"love"}, "💕": {"love"},
+	"😳": {"embarrassed"}, "😅": {"embarrassed"},
+	"🤔": {"thinking"},
+	"😴": {"sleepy"}, "🥱": {"sleepy"},
+	"😎": {"cool"},
+	"🤢": {"disgusted"}, "🤮": {"disgusted"},
+	"🙄": {"annoyed"}, "😒": {"annoyed"},
+	"😏": {"smug"},
+}
+
+// EmotionSlugs: Slugs de emoção associados ao emoji.
+// resolver (opcional) acrescenta keywords CategoryEmotion que tenham o emoji como alias.
+func EmotionSlugs(emoji string, resolver *KeywordResolver) []string {
+	n := NormalizeEmoji(emoji)
+	slugs := append([]string(nil), emojiEmotions[n]...)
+	if resolver != nil {
+		if k, ok := resolver.Lookup(n); ok && k.Category == CategoryEmotion && !containsString(slugs, k.Slug) {
+			slugs = append(slugs, k.Slug)
+		}
+	}
+	return slugs
+}
+
+// ==========================================================
+// 3. ENDPOINT (Sugestão por Emoji)
+// ==========================================================
+
+// EmojiLookupQuery: "?emojis=😢👍🏽". Aceita vários emojis colados ou separados.
+type EmojiLookupQuery struct {
+	Emojis string `form:"emojis" binding:"required,max=64"`
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=50"`
+}
+
+type EmojiLookupResponse struct {
+	Emojis   []string          `json:"emojis"` // Emojis reconhecidos (normalizados).
+	Stickers []StickerResponse `json:"stickers"`
+}
+
+/*
+REGRAS DE EMOJI:
+1.  Normalização: Tons de pele e seletores de variação são removidos ("👍🏽" = "👍", "❤️" = "❤"). Sequências ZWJ, bandeiras e keycaps continuam inteiras.
+2.  Armazenamento: 'Sticker.Emojis' é salvo como o Maker enviou. Só as chaves do índice e da consulta são normalizadas (NormalizeEmojis).
+3.  Correspondência: Emoji exato vale mais; o primeiro componente de uma sequência ZWJ ("👩‍💻" -> "👩") vale como parcial.
+4.  Emoção: Emojis também encontram stickers com a keyword CategoryEmotion correspondente ("😢" -> "sad").
+5.  Visibilidade: Só stickers visíveis, não moderados e dentro da classificação do usuário.
+*/
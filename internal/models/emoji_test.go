@@ -0,0 +1,99 @@
+package models
+
+import "testing"
+
+func TestSplitEmojis(t *testing.T) {
+	cases := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{"colados", "😢👍🏽", []string{"😢", "👍🏽"}},
+		{"texto misturado", "oi 😭 tchau", []string{"😭"}},
+		{"sequência ZWJ", "👩‍💻❤️", []string{"👩‍💻", "❤️"}},
+		{"bandeira e keycap", "🇧🇷1️⃣", []string{"🇧🇷", "1️⃣"}},
+		{"dígito comum", "123", nil},
+		{"modificador solto", "🏽", nil},
+	}
+	for _, tc := range cases {
+		got := SplitEmojis(tc.input)
+		if len(got) != len(tc.want) {
+			t.Errorf("%s: %q, want %q", tc.name, got, tc.want)
+			continue
+		}
+		for i := range tc.want {
+			if got[i] != tc.want[i] {
+				t.Errorf("%s: %q, want %q", tc.name, got, tc.want)
+			}
+		}
+	}
+}
+
+func TestNormalizeEmoji(t *testing.T) {
+	cases := []struct {
+		input    string
+		want     string
+		wantBase string
+	}{
+		{"👍🏽", "👍", "👍"},
+		{"❤️", "❤", "❤"},
+		{"👩🏻‍💻", "👩‍💻", "👩"},
+		{"🇧🇷", "🇧🇷", "🇧🇷"},
+	}
+	for _, tc := range cases {
+		if got := NormalizeEmoji(tc.input); got != tc.want {
+			t.Errorf("NormalizeEmoji(%q) = %q, want %q", tc.input, got, tc.want)
+		}
+		if got := EmojiBase(tc.input); got != tc.wantBase {
+			t.Errorf("EmojiBase(%q) = %q, want %q", tc.input, got, tc.wantBase)
+		}
+	}
+}
+
+func TestNormalizeEmojisKeepsInput(t *testing.T) {
+	input := []string{"👍🏽👍", "❤️", "😢"}
+	got := NormalizeEmojis(input)
+	want := []string{"👍", "❤", "😢"}
+	if len(got) != len(want) {
+		t.Fatalf("%q, want %q", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("%q, want %q", got, want)
+		}
+	}
+	if input[0] != "👍🏽👍" || input[1] != "❤️" {
+		t.Errorf("entrada alterada: %q", input)
+	}
+}
+
+func TestEmotionSlugs(t *testing.T) {
+	resolver := NewKeywordResolver([]Keyword{
+		{ID: 1, Slug: "hype", Category: CategoryEmotion, Aliases: []string{"🔥"}},
+		{ID: 2, Slug: "pizza", Category: CategoryGeneral, Aliases: []string{"🍕"}},
+	})
+	cases := []struct {
+		name     string
+		emoji    string
+		resolver *KeywordResolver
+		want     []string
+	}{
+		{"mapa padrão", "😭", nil, []string{"crying", "sad"}},
+		{"com variação", "❤️", nil, []string{"love"}},
+		{"alias de emoção", "🔥", resolver, []string{"hype"}},
+		{"alias fora de emoção", "🍕", resolver, nil},
+		{"sem emoção", "🍕", nil, nil},
+	}
+	for _, tc := range cases {
+		got := EmotionSlugs(tc.emoji, tc.resolver)
+		if len(got) != len(tc.want) {
+			t.Errorf("%s: %v, want %v", tc.name, got, tc.want)
+			continue
+		}
+		for i := range tc.want {
+			if got[i] != tc.want[i] {
+				t.Errorf("%s: %v, want %v", tc.name, got, tc.want)
+			}
+		}
+	}
+}
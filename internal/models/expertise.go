@@ -0,0 +1,239 @@
+package models
+
+import (
+	"math"
+	"otamaker-api/internal/constants"
+	"sort"
+	"time"
+)
+
+// ==========================================================
+// 1. PESOS DE ESPECIALIDADE (MakerKeyword.Weight)
+// ==========================================================
+
+// Sinais por item. Criar pesa mais que favoritar; engajamento recebido soma por cima.
+const (
+	expertiseCreatedSticker  = 3.0
+	expertiseCreatedPack     = 5.0
+	expertiseFavoriteSticker = 1.0
+	expertiseFavoritePack    = 2.0
+	expertiseEngagement      = 2.0 // Multiplica log10(1 + downloads + 2*likes).
+	expertiseAnimeFactor     = 0.5 // Keywords do anime do item contam pela metade.
+)
+
+const (
+	// ExpertiseHalfLife: Um sticker criado há 90 dias vale metade de um criado hoje.
+	ExpertiseHalfLife = 90 * 24 * time.Hour
+	// expertiseScale: Pontuação bruta que leva o peso a ~63. A escala é absoluta (não relativa ao Maker),
+	// assim "peso >= 80" significa o mesmo esforço para qualquer um (ver Badges).
+	expertiseScale = 40.0
+	// ExpertiseMinWeight: Abaixo disso o vínculo é apagado.
+	ExpertiseMinWeight = 1
+	ExpertiseTopSize   = 5
+)
+
+// ExpertiseInput: O que o Job carrega por Maker.
+// Stickers: OriginalMakerID = Maker (crédito autoral, não posse). Packs: IDMaker = Maker.
+// Favoritos: já resolvidos para as entidades (as datas vêm do vínculo MakerXFavorite.CreatedAt).
+type ExpertiseInput struct {
+	IDMaker  int64
+	Stickers []Sticker
+	Packs    []Pack
+
+	FavoriteStickers []FavoriteSticker
+	FavoritePacks    []FavoritePack
+
+	// AnimeKeywords: Anime -> slugs (cache Anime.Keywords). Opcional.
+	// Faz um sticker de Luffy contar para "one_piece" mesmo sem a tag.
+	AnimeKeywords map[int64][]string
+}
+
+type FavoriteSticker struct {
+	Sticker Sticker
+	SavedAt time.Time
+}
+
+type FavoritePack struct {
+	Pack    Pack
+	SavedAt time.Time
+}
+
+// expertiseDecay: 1 para hoje, 0.5 após uma meia-vida.
+func expertiseDecay(at, now time.Time) float64 {
+	age := now.Sub(at)
+	if age <= 0 {
+		return 1
+	}
+	return math.Pow(0.5, float64(age)/float64(ExpertiseHalfLife))
+}
+
+func expertiseEngagementScore(downloads, likes uint64) float64 {
+	return expertiseEngagement * math.Log10(1+float64(downloads)+2*float64(likes))
+}
+
+// ComputeMakerExpertise calcula os pesos (0-100) do Maker.
+// keywords: slug -> Keyword (pendentes e slugs desconhecidos são ignorados).
+func ComputeMakerExpertise(in ExpertiseInput, keywords map[string]Keyword, now time.Time) []MakerKeyword {
+	scores := map[int64]float64{}
+	add := func(slugs []string, idAnime int64, points float64) {
+		seen := map[int64]bool{}
+		credit := func(slug string, factor float64) {
+			k, ok := keywords[slug]
+			if !ok || k.IsPending || seen[k.ID] {
+				return
+			}
+			seen[k.ID] = true
+			scores[k.ID] += points * factor
+		}
+		for _, slug := range slugs {
+			credit(slug, 1)
+		}
+		for _, slug := range in.AnimeKeywords[idAnime] {
+			credit(slug, expertiseAnimeFactor)
+		}
+	}
+
+	for _, s := range in.Stickers {
+		if !countsForExpertise(&s) {
+			continue
+		}
+		var idAnime int64
+		if s.IDAnime != nil {
+			idAnime = *s.IDAnime
+		}
+		points := (expertiseCreatedSticker + expertiseEngagementScore(s.DownloadsCount, s.LikesCount)) * expertiseDecay(s.CreatedAt, now)
+		add(s.Keywords, idAnime, points)
+	}
+	for _, p := range in.Packs {
+		if !packCountsForExpertise(&p) {
+			continue
+		}
+		at := p.CreatedAt
+		if p.PublishedAt != nil {
+			at = *p.PublishedAt
+		}
+		points := (expertiseCreatedPack + expertiseEngagementScore(p.DownloadsCount, p.LikesCount)) * expertiseDecay(at, now)
+		add(p.Keywords, p.IDAnime, points)
+	}
+	for _, f := range in.FavoriteStickers {
+		if !countsForExpertise(&f.Sticker) {
+			continue
+		}
+		var idAnime int64
+		if f.Sticker.IDAnime != nil {
+			idAnime = *f.Sticker.IDAnime
+		}
+		add(f.Sticker.Keywords, idAnime, expertiseFavoriteSticker*expertiseDecay(f.SavedAt, now))
+	}
+	for _, f := range in.FavoritePacks {
+		if !packCountsForExpertise(&f.Pack) {
+			continue
+		}
+		add(f.Pack.Keywords, f.Pack.IDAnime, expertiseFavoritePack*expertiseDecay(f.SavedAt, now))
+	}
+
+	out := make([]MakerKeyword, 0, len(scores))
+	for id, score := range scores {
+		weight := int(math.Round(100 * (1 - math.Exp(-score/expertiseScale))))
+		if weight < ExpertiseMinWeight {
+			continue
+		}
+		out = append(out, MakerKeyword{IDMaker: in.IDMaker, IDKeyword: id, Weight: min(weight, 100), CreatedAt: now})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].IDKeyword < out[j].IDKeyword })
+	return out
+}
+
+// countsForExpertise: Só sticker público conta (criado ou favoritado). Privado (IsVisible=false) fica de fora.
+func countsForExpertise(s *Sticker) bool {
+	return !s.IsDeleted && !s.IsModerated && s.IsVisible
+}
+
+// packCountsForExpertise: Só pack publicado, não deletado e não banido conta (criado ou favoritado).
+func packCountsForExpertise(p *Pack) bool {
+	return !p.IsDeleted && p.IDModerationBanned == nil && p.IsPublished()
+}
+
+// ExpertiseChange: Vínculo cujo peso mudou (Old = 0 para novo, New = 0 para removido).
+type ExpertiseChange struct {
+	IDKeyword int64
+	Old       int
+	New       int
+}
+
+// DiffMakerKeywords compara o estado gravado com o calculado.
+// upsert: novos ou com peso diferente (CreatedAt original preservado). remove: sem sinal suficiente.
+func DiffMakerKeywords(current, computed []MakerKeyword) (upsert, remove []MakerKeyword, changes []ExpertiseChange) {
+	old := make(map[int64]MakerKeyword, len(current))
+	for _, mk := range current {
+		old[mk.IDKeyword] = mk
+	}
+	for _, mk := range computed {
+		prev, ok := old[mk.IDKeyword]
+		delete(old, mk.IDKeyword)
+		if ok && prev.Weight == mk.Weight {
+			continue
+		}
+		if ok {
+			mk.CreatedAt = prev.CreatedAt
+		}
+		upsert = append(upsert, mk)
+		changes = append(changes, ExpertiseChange{IDKeyword: mk.IDKeyword, Old: prev.Weight, New: mk.Weight})
+	}
+	for _, mk := range current {
+		if _, gone := old[mk.IDKeyword]; gone {
+			remove = append(remove, mk)
+			changes = append(changes, ExpertiseChange{IDKeyword: mk.IDKeyword, Old: mk.Weight})
+		}
+	}
+	return upsert, remove, changes
+}
+
+// ==========================================================
+// 2. PERFIL (Top Especialidades)
+// ==========================================================
+
+type ExpertiseTagResponse struct {
+	Keyword KeywordResponse `json:"keyword"`
+	Weight  int             `json:"weight"`
+}
+
+// TopExpertise: Maiores pesos do Maker para o perfil público.
+// keywords: carregadas em lote (chave = ID). Pendentes não aparecem.
+func TopExpertise(rows []MakerKeyword, keywords map[int64]Keyword, lang constants.Language, limit int) []ExpertiseTagResponse {
+	sorted := append([]MakerKeyword(nil), rows...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Weight != sorted[j].Weight {
+			return sorted[i].Weight > sorted[j].Weight
+		}
+		return sorted[i].IDKeyword < sorted[j].IDKeyword
+	})
+
+	if limit <= 0 {
+		limit = ExpertiseTopSize
+	}
+	out := make([]ExpertiseTagResponse, 0, limit)
+	for _, mk := range sorted {
+		if len(out) == limit {
+			break
+		}
+		k, ok := keywords[mk.IDKeyword]
+		if !ok || k.IsPending {
+			continue
+		}
+		out = append(out, ExpertiseTagResponse{Keyword: k.ToResponse(lang), Weight: mk.Weight})
+	}
+	return out
+}
+
+/*
+REGRAS DE ESPECIALIDADE:
+1.  Origem: O peso vem das keywords dos stickers criados (crédito autoral), dos packs publicados e dos favoritos do Maker.
+2.  Engajamento: Downloads e likes do conteúdo criado somam por cima do valor base (escala logarítmica).
+3.  Recência: Cada item decai pela metade a cada 90 dias. Quem parou de produzir sobre um tema perde o peso aos poucos.
+4.  Escala: 0 a 100, absoluta e saturada. Um peso alto exige volume e engajamento, não apenas proporção.
+5.  Anime: Keywords do anime do item contam pela metade (Luffy conta para "one_piece").
+6.  Limpeza: Conteúdo apagado, moderado, banido ou privado não conta, nem como criação nem como favorito. Pesos abaixo de 1 são removidos.
+7.  Job: Recalcula periodicamente e faz upsert em MakerKeyword apenas do que mudou (DiffMakerKeywords também devolve as mudanças de peso).
+8.  Perfil: Exibe as 5 maiores especialidades (keywords pendentes ficam de fora).
+*/
@@ -0,0 +1,121 @@
+package models
+
+import (
+	"otamaker-api/internal/constants"
+	"testing"
+	"time"
+)
+
+func TestComputeMakerExpertise(t *testing.T) {
+	now := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	old := now.Add(-ExpertiseHalfLife)
+	onePiece := int64(20)
+	banned := int64(99)
+	keywords := map[string]Keyword{
+		"luffy":     {ID: 1, Slug: "luffy"},
+		"one_piece": {ID: 2, Slug: "one_piece"},
+		"crying":    {ID: 3, Slug: "crying"},
+		"pendente":  {ID: 4, Slug: "pendente", IsPending: true},
+	}
+	weights := func(in ExpertiseInput) map[int64]int {
+		out := map[int64]int{}
+		for _, mk := range ComputeMakerExpertise(in, keywords, now) {
+			out[mk.IDKeyword] = mk.Weight
+		}
+		return out
+	}
+	live := Sticker{IsVisible: true, CreatedAt: now, Keywords: []string{"luffy", "pendente"}, IDAnime: &onePiece}
+	cases := []struct {
+		name  string
+		in    ExpertiseInput
+		check func(w map[int64]int) bool
+	}{
+		{"sticker criado", ExpertiseInput{Stickers: []Sticker{live}, AnimeKeywords: map[int64][]string{onePiece: {"one_piece"}}},
+			func(w map[int64]int) bool { return w[1] > w[2] && w[2] > 0 && w[4] == 0 }},
+		{"decaimento", ExpertiseInput{Stickers: []Sticker{{IsVisible: true, CreatedAt: old, Keywords: []string{"luffy"}}, {IsVisible: true, CreatedAt: now, Keywords: []string{"crying"}}}},
+			func(w map[int64]int) bool { return w[1] < w[3] && w[1] > 0 }},
+		{"engajamento", ExpertiseInput{Stickers: []Sticker{{IsVisible: true, CreatedAt: now, Keywords: []string{"luffy"}, DownloadsCount: 1000}, {IsVisible: true, CreatedAt: now, Keywords: []string{"crying"}}}},
+			func(w map[int64]int) bool { return w[1] > w[3] }},
+		{"conteúdo removido não conta", ExpertiseInput{
+			Stickers:         []Sticker{{CreatedAt: now, Keywords: []string{"luffy"}}, {IsVisible: true, IsModerated: true, CreatedAt: now, Keywords: []string{"luffy"}}},
+			Packs:            []Pack{{Status: PackDraft, CreatedAt: now, Keywords: []string{"luffy"}}, {Status: PackPublished, IDModerationBanned: &banned, Keywords: []string{"luffy"}}},
+			FavoriteStickers: []FavoriteSticker{{Sticker: Sticker{IsVisible: true, IsDeleted: true, Keywords: []string{"luffy"}}, SavedAt: now}},
+			FavoritePacks:    []FavoritePack{{Pack: Pack{Status: PackPublished, IsDeleted: true, Keywords: []string{"luffy"}}, SavedAt: now}},
+		}, func(w map[int64]int) bool { return len(w) == 0 }},
+		{"favoritos contam", ExpertiseInput{
+			FavoriteStickers: []FavoriteSticker{{Sticker: Sticker{IsVisible: true, Keywords: []string{"crying"}}, SavedAt: now}},
+			FavoritePacks:    []FavoritePack{{Pack: Pack{Status: PackPublished, Keywords: []string{"luffy"}}, SavedAt: now}},
+		}, func(w map[int64]int) bool { return w[1] > w[3] && w[3] > 0 }},
+	}
+	for _, tc := range cases {
+		if w := weights(tc.in); !tc.check(w) {
+			t.Errorf("%s: pesos = %v", tc.name, w)
+		}
+	}
+}
+
+func TestExpertiseSaturates(t *testing.T) {
+	now := time.Now()
+	stickers := make([]Sticker, 200)
+	for i := range stickers {
+		stickers[i] = Sticker{IsVisible: true, CreatedAt: now, Keywords: []string{"luffy"}, DownloadsCount: 10000}
+	}
+	out := ComputeMakerExpertise(ExpertiseInput{IDMaker: 7, Stickers: stickers}, map[string]Keyword{"luffy": {ID: 1}}, now)
+	if len(out) != 1 || out[0].Weight != 100 || out[0].IDMaker != 7 {
+		t.Errorf("out = %+v, want peso 100", out)
+	}
+}
+
+func TestDiffMakerKeywords(t *testing.T) {
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	current := []MakerKeyword{{IDKeyword: 1, Weight: 10, CreatedAt: created}, {IDKeyword: 2, Weight: 20}, {IDKeyword: 3, Weight: 30}}
+	computed := []MakerKeyword{{IDKeyword: 1, Weight: 15}, {IDKeyword: 2, Weight: 20}, {IDKeyword: 4, Weight: 5}}
+	upsert, remove, changes := DiffMakerKeywords(current, computed)
+	if len(upsert) != 2 || !upsert[0].CreatedAt.Equal(created) || upsert[1].IDKeyword != 4 {
+		t.Errorf("upsert = %+v", upsert)
+	}
+	if len(remove) != 1 || remove[0].IDKeyword != 3 {
+		t.Errorf("remove = %+v", remove)
+	}
+	want := []ExpertiseChange{{1, 10, 15}, {4, 0, 5}, {3, 30, 0}}
+	if len(changes) != len(want) {
+		t.Fatalf("changes = %+v", changes)
+	}
+	for i := range want {
+		if changes[i] != want[i] {
+			t.Errorf("changes[%d] = %+v, want %+v", i, changes[i], want[i])
+		}
+	}
+}
+
+func TestTopExpertise(t *testing.T) {
+	rows := []MakerKeyword{{IDKeyword: 1, Weight: 40}, {IDKeyword: 2, Weight: 90}, {IDKeyword: 3, Weight: 90}, {IDKeyword: 4, Weight: 70}}
+	keywords := map[int64]Keyword{
+		1: {ID: 1, Slug: "crying"},
+		2: {ID: 2, Slug: "luffy"},
+		3: {ID: 3, Slug: "novo", IsPending: true},
+		4: {ID: 4, Slug: "one_piece", Name: map[string]string{"pt_br": "One Piece"}},
+	}
+	cases := []struct {
+		limit int
+		want  []string
+	}{
+		{0, []string{"luffy", "one_piece", "crying"}},
+		{2, []string{"luffy", "one_piece"}},
+	}
+	for _, tc := range cases {
+		out := TopExpertise(rows, keywords, constants.PT_BR, tc.limit)
+		if len(out) != len(tc.want) {
+			t.Errorf("limite %d: %+v", tc.limit, out)
+			continue
+		}
+		for i, slug := range tc.want {
+			if out[i].Keyword.Slug != slug {
+				t.Errorf("limite %d: [%d] = %q, want %q", tc.limit, i, out[i].Keyword.Slug, slug)
+			}
+		}
+	}
+	if rows[0].IDKeyword != 1 {
+		t.Error("TopExpertise reordenou a entrada")
+	}
+}
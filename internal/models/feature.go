@@ -0,0 +1,221 @@
+package models
+
+import (
+	"errors"
+	"otamaker-api/internal/constants"
+	"sort"
+	"time"
+)
+
+// ==========================================================
+// 1. AGENDAMENTO EDITORIAL (Destaques)
+// ==========================================================
+
+var (
+	ErrInvalidFeatureWindow = errors.New("janela de destaque inválida: fim deve ser depois do início")
+	ErrInvalidFeatureTarget = errors.New("apenas animes e packs podem ser destacados")
+)
+
+// FeatureSchedule: Janela de destaque de um Anime ou Pack na Home.
+// O Scheduler liga/desliga 'IsFeatured' no alvo conforme as janelas ativas.
+type FeatureSchedule struct {
+	ID int64 `json:"id" db:"id" gorm:"primaryKey"`
+
+	// ALVO (Reaproveita os tipos da moderação: apenas TargetAnime e TargetPack são aceitos).
+	TargetType TargetType `json:"target_type" db:"target_type" gorm:"index:idx_feature_target"`
+	IDTarget   int64      `json:"id_target" db:"id_target" gorm:"index:idx_feature_target"`
+
+	// Slot: Posição no carrossel da Home (0 = primeiro).
+	Slot int16 `json:"slot" db:"slot"`
+
+	// JANELA
+	StartsAt time.Time `json:"starts_at" db:"starts_at" gorm:"index"`
+	EndsAt   time.Time `json:"ends_at" db:"ends_at" gorm:"index"`
+
+	// Segmentação: Null = todos os idiomas.
+	Language *constants.Language `json:"language" db:"language"`
+
+	// AUDITORIA
+	IDMakerEditor int64     `json:"id_maker_editor" db:"id_maker_editor"`
+	CreatedAt     time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// FeatureTarget: Chave (Tipo + ID) de um item destacável.
+type FeatureTarget struct {
+	TargetType TargetType
+	IDTarget   int64
+}
+
+// FeatureFlagChange: Alteração que o Scheduler deve aplicar em 'IsFeatured'.
+type FeatureFlagChange struct {
+	FeatureTarget
+	IsFeatured bool
+}
+
+// ==========================================================
+// 2. INPUTS E DTOs
+// ==========================================================
+
+type CreateFeatureScheduleInput struct {
+	TargetType TargetType `json:"target_type" binding:"required,oneof=2 4"` // TargetPack, TargetAnime
+	IDTarget   int64      `json:"id_target" binding:"required,gt=0"`
+	Slot       int16      `json:"slot" binding:"gte=0"`
+	StartsAt   time.Time  `json:"starts_at" binding:"required"`
+	EndsAt     time.Time  `json:"ends_at" binding:"required,gtfield=StartsAt"`
+	Language   *string    `json:"language"` // "pt-BR", "en"... Normalizado via FeatureLanguage (não suportado = erro).
+}
+
+type UpdateFeatureScheduleInput struct {
+	Slot     *int16     `json:"slot" binding:"omitempty,gte=0"`
+	StartsAt *time.Time `json:"starts_at"`
+	EndsAt   *time.Time `json:"ends_at"`
+	Language *string    `json:"language"`
+}
+
+// HomeFeedResponse: Destaques vigentes na ordem dos slots.
+type HomeFeedResponse struct {
+	Animes []AnimeResponse `json:"animes"`
+	Packs  []PackResponse  `json:"packs"`
+}
+
+// ==========================================================
+// 3. LÓGICA DO SCHEDULER
+// ==========================================================
+
+// Validate garante um alvo destacável e uma janela coerente.
+func (f *FeatureSchedule) Validate() error {
+	if f.TargetType != TargetAnime && f.TargetType != TargetPack {
+		return ErrInvalidFeatureTarget
+	}
+	if !f.EndsAt.After(f.StartsAt) {
+		return ErrInvalidFeatureWindow
+	}
+	return nil
+}
+
+// IsLive: Janela vigente no instante 'now' (início inclusivo, fim exclusivo).
+func (f *FeatureSchedule) IsLive(now time.Time) bool {
+	return !now.Before(f.StartsAt) && now.Before(f.EndsAt)
+}
+
+// MatchesLanguage: Sem segmentação, vale para todos.
+func (f *FeatureSchedule) MatchesLanguage(lang constants.Language) bool {
+	return f.Language == nil || *f.Language == lang
+}
+
+// FeatureLanguage converte o idioma do input do Admin. Vazio = todos os idiomas.
+// Idioma não suportado é recusado (NormalizeLanguage cairia em pt_br e segmentaria a janela errada).
+func FeatureLanguage(input *string) (*constants.Language, error) {
+	if input == nil || *input == "" {
+		return nil, nil
+	}
+	if !constants.IsSupported(*input) {
+		return nil, ErrUnsupportedLanguage
+	}
+	lang := constants.NormalizeLanguage(*input)
+	return &lang, nil
+}
+
+// PlanFeatureFlags compara as janelas com o estado atual dos alvos e devolve o que mudar.
+// 'current' contém os alvos que hoje estão com IsFeatured=true.
+// Apenas alvos que possuem agendamento são reconciliados; destaques manuais sem agenda ficam intactos.
+func PlanFeatureFlags(schedules []FeatureSchedule, current map[FeatureTarget]bool, now time.Time) []FeatureFlagChange {
+	desired := make(map[FeatureTarget]bool, len(schedules))
+	for i := range schedules {
+		key := FeatureTarget{schedules[i].TargetType, schedules[i].IDTarget}
+		desired[key] = desired[key] || schedules[i].IsLive(now)
+	}
+
+	changes := make([]FeatureFlagChange, 0)
+	for target, live := range desired {
+		if current[target] != live {
+			changes = append(changes, FeatureFlagChange{FeatureTarget: target, IsFeatured: live})
+		}
+	}
+
+	// Ordem determinística para logs e testes.
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].TargetType != changes[j].TargetType {
+			return changes[i].TargetType < changes[j].TargetType
+		}
+		return changes[i].IDTarget < changes[j].IDTarget
+	})
+	return changes
+}
+
+// HomeFeedSlots devolve os IDs vigentes para o idioma do usuário, ordenados por slot.
+// Um mesmo alvo em várias janelas aparece uma vez só, no menor slot.
+// lang: idioma enviado pelo cliente ("pt-BR", "fr"...). Idioma não suportado recebe o feed padrão
+// (só janelas sem segmentação), nunca as janelas de pt_br.
+func HomeFeedSlots(schedules []FeatureSchedule, lang string, now time.Time) (animeIDs, packIDs []int64) {
+	supported := constants.IsSupported(lang)
+	viewer := constants.NormalizeLanguage(lang)
+
+	live := make([]FeatureSchedule, 0, len(schedules))
+	for _, f := range schedules {
+		if f.IsLive(now) && (f.Language == nil || (supported && f.MatchesLanguage(viewer))) {
+			live = append(live, f)
+		}
+	}
+
+	sort.SliceStable(live, func(i, j int) bool {
+		if live[i].Slot != live[j].Slot {
+			return live[i].Slot < live[j].Slot
+		}
+		return live[i].StartsAt.Before(live[j].StartsAt)
+	})
+
+	seen := make(map[FeatureTarget]bool, len(live))
+	for _, f := range live {
+		key := FeatureTarget{f.TargetType, f.IDTarget}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		switch f.TargetType {
+		case TargetAnime:
+			animeIDs = append(animeIDs, f.IDTarget)
+		case TargetPack:
+			packIDs = append(packIDs, f.IDTarget)
+		}
+	}
+	return animeIDs, packIDs
+}
+
+// FilterHomeFeed: Segunda etapa do Home Feed. O Service carrega os IDs de HomeFeedSlots em lote
+// e esta função descarta o que não pode aparecer, mantendo a ordem dos slots.
+// ceiling: ViewerMaxRating do usuário (destaque adulto não aparece para quem não liberou).
+func FilterHomeFeed(animeIDs, packIDs []int64, animes map[int64]*Anime, packs map[int64]*Pack, ceiling ContentRating) ([]*Anime, []*Pack) {
+	outAnimes := make([]*Anime, 0, len(animeIDs))
+	for _, id := range animeIDs {
+		a, ok := animes[id]
+		if !ok || !a.IsVisible || a.IsModerated || a.MergedIntoID != nil || !ceiling.Allows(a.ContentRating) {
+			continue
+		}
+		outAnimes = append(outAnimes, a)
+	}
+
+	outPacks := make([]*Pack, 0, len(packIDs))
+	for _, id := range packIDs {
+		p, ok := packs[id]
+		if !ok || !p.IsPublished() || p.IsDeleted || p.IDModerationBanned != nil || !ceiling.Allows(p.ContentRating) {
+			continue
+		}
+		outPacks = append(outPacks, p)
+	}
+	return outAnimes, outPacks
+}
+
+/*
+REGRAS DE DESTAQUE EDITORIAL:
+1.  Janelas: Todo destaque agendado tem início e fim. Fora da janela, o Scheduler desliga 'IsFeatured'.
+2.  Alvos: Apenas Animes (TargetAnime) e Packs (TargetPack) podem ser destacados.
+3.  Slots: A Home exibe os itens ordenados por 'Slot'. Empate = quem começou antes.
+4.  Idioma: Janelas com 'Language' só aparecem para usuários daquele idioma. Idioma não suportado vê apenas as janelas sem segmentação.
+    O Admin não pode segmentar para idioma não suportado (FeatureLanguage). O flag 'IsFeatured' é global.
+5.  Sobreposição: Se um alvo tem várias janelas, ele fica destacado enquanto QUALQUER uma estiver vigente.
+6.  Scheduler: Roda periodicamente (ex: a cada minuto), chama 'PlanFeatureFlags' e grava apenas as mudanças.
+7.  Home Feed: Itens ocultos, moderados, deletados ou acima do teto de classificação do usuário são descartados mesmo com janela vigente (FilterHomeFeed sobre os IDs de HomeFeedSlots).
+*/
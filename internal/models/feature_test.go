@@ -0,0 +1,166 @@
+package models
+
+import (
+	"errors"
+	"otamaker-api/internal/constants"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestFeatureScheduleValidate(t *testing.T) {
+	t0 := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name string
+		f    FeatureSchedule
+		want error
+	}{
+		{"anime válido", FeatureSchedule{TargetType: TargetAnime, StartsAt: t0, EndsAt: t0.Add(time.Hour)}, nil},
+		{"pack válido", FeatureSchedule{TargetType: TargetPack, StartsAt: t0, EndsAt: t0.Add(time.Hour)}, nil},
+		{"alvo não destacável", FeatureSchedule{TargetType: TargetSticker, StartsAt: t0, EndsAt: t0.Add(time.Hour)}, ErrInvalidFeatureTarget},
+		{"fim antes do início", FeatureSchedule{TargetType: TargetPack, StartsAt: t0, EndsAt: t0}, ErrInvalidFeatureWindow},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.f.Validate(); got != tc.want {
+				t.Fatalf("Validate = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFeatureLanguage(t *testing.T) {
+	str := func(s string) *string { return &s }
+
+	cases := []struct {
+		name    string
+		in      *string
+		want    *constants.Language
+		wantErr error
+	}{
+		{"nil = todos", nil, nil, nil},
+		{"vazio = todos", str(""), nil, nil},
+		{"formato do cliente", str("en-US"), langPtr(constants.EN_US), nil},
+		{"prefixo", str("es"), langPtr(constants.ES_ES), nil},
+		{"não suportado", str("fr"), nil, ErrUnsupportedLanguage},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := FeatureLanguage(tc.in)
+			if !errors.Is(err, tc.wantErr) || !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("FeatureLanguage = (%v, %v), want (%v, %v)", got, err, tc.want, tc.wantErr)
+			}
+		})
+	}
+}
+
+func langPtr(l constants.Language) *constants.Language { return &l }
+
+func TestPlanFeatureFlags(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	live := FeatureSchedule{TargetType: TargetPack, IDTarget: 1, StartsAt: now.Add(-time.Hour), EndsAt: now.Add(time.Hour)}
+	ended := FeatureSchedule{TargetType: TargetPack, IDTarget: 2, StartsAt: now.Add(-2 * time.Hour), EndsAt: now}
+	overlapEnded := FeatureSchedule{TargetType: TargetPack, IDTarget: 1, StartsAt: now.Add(-2 * time.Hour), EndsAt: now.Add(-time.Hour)}
+
+	cases := []struct {
+		name      string
+		schedules []FeatureSchedule
+		current   map[FeatureTarget]bool
+		want      []FeatureFlagChange
+	}{
+		{"liga janela vigente", []FeatureSchedule{live}, nil,
+			[]FeatureFlagChange{{FeatureTarget{TargetPack, 1}, true}}},
+		{"nada muda se já está ligado", []FeatureSchedule{live}, map[FeatureTarget]bool{{TargetPack, 1}: true},
+			[]FeatureFlagChange{}},
+		{"desliga janela encerrada (fim exclusivo)", []FeatureSchedule{ended}, map[FeatureTarget]bool{{TargetPack, 2}: true},
+			[]FeatureFlagChange{{FeatureTarget{TargetPack, 2}, false}}},
+		{"qualquer janela vigente mantém ligado", []FeatureSchedule{overlapEnded, live}, map[FeatureTarget]bool{{TargetPack, 1}: true},
+			[]FeatureFlagChange{}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := PlanFeatureFlags(tc.schedules, tc.current, now); !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("PlanFeatureFlags = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestHomeFeedSlots(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	window := func(target TargetType, id int64, slot int16, lang *constants.Language) FeatureSchedule {
+		return FeatureSchedule{TargetType: target, IDTarget: id, Slot: slot, Language: lang,
+			StartsAt: now.Add(-time.Hour), EndsAt: now.Add(time.Hour)}
+	}
+	schedules := []FeatureSchedule{
+		window(TargetPack, 10, 2, nil),
+		window(TargetPack, 11, 1, langPtr(constants.PT_BR)),
+		window(TargetPack, 12, 0, langPtr(constants.EN_US)),
+		window(TargetAnime, 20, 0, nil),
+		window(TargetPack, 10, 0, nil), // Mesmo alvo em outro slot: aparece uma vez, no menor.
+	}
+
+	cases := []struct {
+		lang       string
+		wantAnimes []int64
+		wantPacks  []int64
+	}{
+		{"pt-BR", []int64{20}, []int64{10, 11}},
+		{"en", []int64{20}, []int64{12, 10}},
+		{"fr", []int64{20}, []int64{10}},
+		{"", []int64{20}, []int64{10}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.lang, func(t *testing.T) {
+			animes, packs := HomeFeedSlots(schedules, tc.lang, now)
+			if !reflect.DeepEqual(animes, tc.wantAnimes) || !reflect.DeepEqual(packs, tc.wantPacks) {
+				t.Fatalf("HomeFeedSlots = %v %v, want %v %v", animes, packs, tc.wantAnimes, tc.wantPacks)
+			}
+		})
+	}
+}
+
+func TestFilterHomeFeed(t *testing.T) {
+	banned := int64(9)
+	merged := int64(1)
+	animes := map[int64]*Anime{
+		1: {ID: 1, IsVisible: true},
+		2: {ID: 2, IsVisible: false},
+		3: {ID: 3, IsVisible: true, MergedIntoID: &merged},
+		4: {ID: 4, IsVisible: true, ContentRating: RatingSuggestive},
+	}
+	packs := map[int64]*Pack{
+		1: {ID: 1, Status: PackPublished},
+		2: {ID: 2, Status: PackDraft},
+		3: {ID: 3, Status: PackPublished, IDModerationBanned: &banned},
+		4: {ID: 4, Status: PackPublished, ContentRating: RatingAdult},
+		5: {ID: 5, Status: PackPublished, IsDeleted: true},
+	}
+
+	cases := []struct {
+		name       string
+		ceiling    ContentRating
+		wantAnimes []int64
+		wantPacks  []int64
+	}{
+		{"anônimo", RatingSafe, []int64{1}, []int64{1}},
+		{"logado", RatingSuggestive, []int64{1, 4}, []int64{1}},
+		{"adulto liberado", RatingAdult, []int64{1, 4}, []int64{1, 4}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			outAnimes, outPacks := FilterHomeFeed([]int64{1, 2, 3, 4, 99}, []int64{1, 2, 3, 4, 5, 99}, animes, packs, tc.ceiling)
+			var gotAnimes, gotPacks []int64
+			for _, a := range outAnimes {
+				gotAnimes = append(gotAnimes, a.ID)
+			}
+			for _, p := range outPacks {
+				gotPacks = append(gotPacks, p.ID)
+			}
+			if !reflect.DeepEqual(gotAnimes, tc.wantAnimes) || !reflect.DeepEqual(gotPacks, tc.wantPacks) {
+				t.Fatalf("FilterHomeFeed = %v %v, want %v %v", gotAnimes, gotPacks, tc.wantAnimes, tc.wantPacks)
+			}
+		})
+	}
+}
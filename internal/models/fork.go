@@ -0,0 +1,141 @@
+package models
+
+import (
+	"errors"
+	"sort"
+	"time"
+)
+
+// ==========================================================
+// 1. REMIX ("Fork this pack")
+// ==========================================================
+
+var (
+	ErrForkSourceUnavailable = errors.New("pack de origem indisponível para remix")
+	ErrForkNoReusable        = errors.New("pack de origem não possui stickers reutilizáveis")
+	ErrForkOwnPack           = errors.New("não é possível remixar o próprio pack")
+)
+
+// ForkPack cria um novo Rascunho do 'caller' a partir de um pack de outro Maker.
+// Os stickers NÃO são duplicados: o novo pack apenas referencia os reutilizáveis (IsReusable),
+// então o crédito (OriginalMakerID) continua com o autor.
+// stickers: stickers do pack de origem; positions: PackSticker de origem (define a ordem).
+// keywords: keywords vinculadas à origem via PackKeyword (o remix ganha os mesmos pivots).
+func ForkPack(src *Pack, stickers []*Sticker, positions []PackSticker, keywords []*Keyword, caller int64, now time.Time) (Pack, []PackSticker, []PackKeyword, error) {
+	if src.IsDeleted || src.IDModerationBanned != nil || !src.IsPublished() {
+		return Pack{}, nil, nil, ErrForkSourceUnavailable
+	}
+	if src.IDMaker == caller {
+		return Pack{}, nil, nil, ErrForkOwnPack
+	}
+
+	order := make(map[int64]int16, len(positions))
+	for _, ps := range positions {
+		order[ps.IDSticker] = ps.Position
+	}
+
+	reusable := make([]*Sticker, 0, len(stickers))
+	for _, s := range stickers {
+		if s.IsDeleted || s.IsModerated || !s.IsVisible {
+			continue
+		}
+		if s.IsReusable {
+			reusable = append(reusable, s)
+		}
+	}
+	if len(reusable) == 0 {
+		return Pack{}, nil, nil, ErrForkNoReusable
+	}
+	sort.SliceStable(reusable, func(i, j int) bool {
+		return order[reusable[i].ID] < order[reusable[j].ID]
+	})
+
+	srcID := src.ID
+	fork := Pack{
+		IDAnime:      src.IDAnime,
+		IDMaker:      caller,
+		IDPackSource: &srcID,
+		Name:         src.Name,
+		Description:  src.Description,
+		TrayImageURL: src.TrayImageURL,
+		Keywords:     append([]string(nil), src.Keywords...),
+		IsAnimated:   src.IsAnimated,
+		Status:       PackDraft,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+
+		ContentUpdatedAt: &now,
+
+		// Classificação e spoiler acompanham o conteúdo: remix de pack adulto continua adulto.
+		DeclaredRating: src.DeclaredRating,
+		ContentRating:  src.ContentRating,
+		IsSpoiler:      src.IsSpoiler,
+		SpoilerAnimeID: src.SpoilerAnimeID,
+		SpoilerEpisode: src.SpoilerEpisode,
+	}
+
+	// O ID do novo pack só existe após o INSERT. O Service preenche 'IDPack' nas linhas.
+	links := make([]PackSticker, 0, len(reusable))
+	for i, s := range reusable {
+		links = append(links, PackSticker{IDSticker: s.ID, Position: int16(i), CreatedAt: now})
+		s.PacksCount++
+	}
+	fork.StickersCount = uint64(len(links))
+
+	kept := make([]Sticker, 0, len(reusable))
+	for _, s := range reusable {
+		kept = append(kept, *s)
+	}
+	fork.RecomputeRating(kept)
+
+	// Mesmo contrato de 'links': o Service preenche 'IDPack'.
+	tags := make([]PackKeyword, 0, len(keywords))
+	for _, k := range keywords {
+		tags = append(tags, PackKeyword{IDKeyword: k.ID, CreatedAt: now})
+		k.UsageCount++
+	}
+
+	src.ForksCount++
+	return fork, links, tags, nil
+}
+
+// ==========================================================
+// 2. DTO (Bloco "Remixado de")
+// ==========================================================
+
+// PackRemixResponse: Exibido no pack público quando 'IDPackSource' está preenchido.
+type PackRemixResponse struct {
+	IDPack        int64  `json:"id_pack"`
+	Name          string `json:"name"`
+	IDMaker       int64  `json:"id_maker"`
+	MakerNickname string `json:"maker_nickname"`
+	IsAvailable   bool   `json:"is_available"` // False se a origem foi deletada/banida (link some, crédito fica).
+}
+
+// Mapper
+func (p *Pack) ToRemixResponse(owner *Maker) PackRemixResponse {
+	out := PackRemixResponse{
+		IDPack:      p.ID,
+		Name:        p.Name,
+		IDMaker:     p.IDMaker,
+		IsAvailable: !p.IsDeleted && p.IDModerationBanned == nil && p.IsPublished(),
+	}
+	if owner != nil {
+		out.MakerNickname = owner.Nickname
+	}
+	return out
+}
+
+/*
+REGRAS DE REMIX:
+1.  Origem: Só packs publicados, não deletados e não banidos podem ser remixados. O dono não remixa o próprio pack.
+2.  Conteúdo: Entram apenas stickers visíveis e reutilizáveis (IsReusable), inclusive para o caller. A ordem original é mantida.
+3.  Autoria: Stickers são referenciados, nunca copiados. OriginalMakerID permanece com o autor.
+4.  Resultado: O novo pack nasce Rascunho (PackDraft), pertence ao caller e guarda 'IDPackSource' para linhagem.
+5.  Métricas: 'ForksCount' da origem sobe e cada sticker referenciado ganha +1 em PacksCount.
+    Remix na lixeira não conta em 'ForksCount' (Pack.SoftDelete/Restore, trash.go).
+6.  Crédito: A resposta pública exibe o bloco "Remixado de" mesmo que a origem saia do ar (IsAvailable = false).
+7.  Classificação: O remix herda DeclaredRating e spoiler da origem; ContentRating é recalculado pelos stickers referenciados.
+8.  Keywords: O remix copia o cache 'Keywords' e ganha os pivots PackKeyword da origem (UsageCount +1 em cada).
+9.  Purge da Origem: Os remixes perdem 'IDPackSource' (PreparePackPurge). Antes disso o crédito continua visível.
+*/
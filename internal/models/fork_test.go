@@ -0,0 +1,84 @@
+package models
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func forkSource() (*Pack, []*Sticker, []PackSticker) {
+	src := &Pack{ID: 10, IDMaker: 1, Name: "Luffy memes", Status: PackPublished, Keywords: []string{"luffy"},
+		DeclaredRating: RatingSafe}
+	stickers := []*Sticker{
+		{ID: 1, IDMaker: 1, IsReusable: true, IsVisible: true},
+		{ID: 2, IDMaker: 2, IsReusable: false, IsVisible: true}, // Do caller, mas não reutilizável.
+		{ID: 3, IDMaker: 1, IsReusable: true, IsVisible: true, ContentRating: RatingSuggestive},
+		{ID: 4, IDMaker: 1, IsReusable: true, IsVisible: true, IsDeleted: true},
+	}
+	positions := []PackSticker{{IDSticker: 1, Position: 2}, {IDSticker: 2, Position: 0}, {IDSticker: 3, Position: 1}, {IDSticker: 4, Position: 3}}
+	return src, stickers, positions
+}
+
+func TestForkPack(t *testing.T) {
+	now := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+	banned := int64(7)
+	cases := []struct {
+		name      string
+		mutate    func(src *Pack, stickers []*Sticker)
+		caller    int64
+		wantErr   error
+		wantOrder []int64
+	}{
+		{"só reutilizáveis, na ordem da origem", nil, 2, nil, []int64{3, 1}},
+		{"próprio pack", nil, 1, ErrForkOwnPack, nil},
+		{"origem rascunho", func(src *Pack, _ []*Sticker) { src.Status = PackDraft }, 2, ErrForkSourceUnavailable, nil},
+		{"origem banida", func(src *Pack, _ []*Sticker) { src.IDModerationBanned = &banned }, 2, ErrForkSourceUnavailable, nil},
+		{"origem deletada", func(src *Pack, _ []*Sticker) { src.IsDeleted = true }, 2, ErrForkSourceUnavailable, nil},
+		{"nada reutilizável", func(_ *Pack, stickers []*Sticker) {
+			for _, s := range stickers {
+				s.IsReusable = false
+			}
+		}, 2, ErrForkNoReusable, nil},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			src, stickers, positions := forkSource()
+			if tc.mutate != nil {
+				tc.mutate(src, stickers)
+			}
+			keywords := []*Keyword{{ID: 100, Slug: "luffy", UsageCount: 4}}
+
+			fork, links, tags, err := ForkPack(src, stickers, positions, keywords, tc.caller, now)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tc.wantErr)
+			}
+			if tc.wantErr != nil {
+				if src.ForksCount != 0 || keywords[0].UsageCount != 4 {
+					t.Errorf("remix recusado não deve alterar contadores")
+				}
+				return
+			}
+
+			if len(links) != len(tc.wantOrder) {
+				t.Fatalf("links = %d, want %d", len(links), len(tc.wantOrder))
+			}
+			for i, id := range tc.wantOrder {
+				if links[i].IDSticker != id || links[i].Position != int16(i) {
+					t.Errorf("links[%d] = %+v, want sticker %d", i, links[i], id)
+				}
+			}
+			if fork.IDMaker != tc.caller || fork.Status != PackDraft || fork.IDPackSource == nil || *fork.IDPackSource != src.ID {
+				t.Errorf("fork = %+v", fork)
+			}
+			if fork.StickersCount != uint64(len(tc.wantOrder)) || fork.ContentRating != RatingSuggestive {
+				t.Errorf("fork StickersCount/ContentRating = %d/%q", fork.StickersCount, fork.ContentRating)
+			}
+			if src.ForksCount != 1 || stickers[0].PacksCount != 1 || stickers[1].PacksCount != 0 {
+				t.Errorf("ForksCount = %d, PacksCount = %d/%d", src.ForksCount, stickers[0].PacksCount, stickers[1].PacksCount)
+			}
+			if len(tags) != 1 || tags[0].IDKeyword != 100 || keywords[0].UsageCount != 5 {
+				t.Errorf("tags = %+v, UsageCount = %d", tags, keywords[0].UsageCount)
+			}
+		})
+	}
+}
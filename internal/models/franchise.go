@@ -0,0 +1,272 @@
+package models
+
+import (
+	"errors"
+	"otamaker-api/internal/constants"
+	"sort"
+	"time"
+)
+
+// ==========================================================
+// 1. RELAÇÕES ENTRE ANIMES (Grafo)
+// ==========================================================
+
+// AnimeRelationType: Como uma obra se relaciona com outra (leitura: "B é <tipo> de A").
+type AnimeRelationType string
+
+const (
+	RelationSequel      AnimeRelationType = "sequel"      // Continuação
+	RelationPrequel     AnimeRelationType = "prequel"     // Antecessor
+	RelationSideStory   AnimeRelationType = "side_story"  // História paralela
+	RelationParent      AnimeRelationType = "parent"      // Obra principal de um side story
+	RelationSpinOff     AnimeRelationType = "spin_off"    // Derivado
+	RelationOriginal    AnimeRelationType = "original"    // Obra original de um derivado
+	RelationMovie       AnimeRelationType = "movie"       // Filme da franquia
+	RelationSeries      AnimeRelationType = "series"      // Série de origem de um filme
+	RelationAlternative AnimeRelationType = "alternative" // Outra versão da mesma história (remake/reboot)
+)
+
+// Inversa de cada relação. Gravamos sempre as duas direções para leitura O(1) pela página do anime.
+// O mapa é uma bijeção: Inverse(Inverse(t)) == t, então a aresta reversa nunca perde o tipo original.
+var inverseRelation = map[AnimeRelationType]AnimeRelationType{
+	RelationSequel:      RelationPrequel,
+	RelationPrequel:     RelationSequel,
+	RelationSideStory:   RelationParent,
+	RelationParent:      RelationSideStory,
+	RelationSpinOff:     RelationOriginal,
+	RelationOriginal:    RelationSpinOff,
+	RelationMovie:       RelationSeries,
+	RelationSeries:      RelationMovie,
+	RelationAlternative: RelationAlternative,
+}
+
+var relationTranslations = map[AnimeRelationType]map[string]string{
+	RelationSequel:      {string(constants.PT_BR): "Continuação", string(constants.EN_US): "Sequel", string(constants.ES_ES): "Secuela"},
+	RelationPrequel:     {string(constants.PT_BR): "Prelúdio", string(constants.EN_US): "Prequel", string(constants.ES_ES): "Precuela"},
+	RelationSideStory:   {string(constants.PT_BR): "História Paralela", string(constants.EN_US): "Side Story", string(constants.ES_ES): "Historia Paralela"},
+	RelationParent:      {string(constants.PT_BR): "Obra Principal", string(constants.EN_US): "Parent Story", string(constants.ES_ES): "Obra Principal"},
+	RelationSpinOff:     {string(constants.PT_BR): "Spin-off", string(constants.EN_US): "Spin-off", string(constants.ES_ES): "Spin-off"},
+	RelationOriginal:    {string(constants.PT_BR): "Obra Original", string(constants.EN_US): "Original Work", string(constants.ES_ES): "Obra Original"},
+	RelationMovie:       {string(constants.PT_BR): "Filme", string(constants.EN_US): "Movie", string(constants.ES_ES): "Película"},
+	RelationSeries:      {string(constants.PT_BR): "Série", string(constants.EN_US): "Series", string(constants.ES_ES): "Serie"},
+	RelationAlternative: {string(constants.PT_BR): "Versão Alternativa", string(constants.EN_US): "Alternative Version", string(constants.ES_ES): "Versión Alternativa"},
+}
+
+var ErrInvalidRelation = errors.New("relação inválida entre animes")
+
+// IsValid verifica se o tipo de relação existe.
+func (t AnimeRelationType) IsValid() bool {
+	_, ok := inverseRelation[t]
+	return ok
+}
+
+// Inverse: Sequel -> Prequel, SideStory -> Parent...
+func (t AnimeRelationType) Inverse() AnimeRelationType {
+	return inverseRelation[t]
+}
+
+// Translate usa Language.Get para fallback.
+func (t AnimeRelationType) Translate(lang constants.Language) string {
+	return lang.Get(relationTranslations[t])
+}
+
+// AnimeRelation: Aresta do grafo. "IDAnimeRelated é <Type> de IDAnime".
+type AnimeRelation struct {
+	IDAnime        int64             `json:"id_anime" db:"id_anime" gorm:"primaryKey"`
+	IDAnimeRelated int64             `json:"id_anime_related" db:"id_anime_related" gorm:"primaryKey"`
+	Type           AnimeRelationType `json:"type" db:"type"`
+	CreatedAt      time.Time         `json:"created_at" db:"created_at"`
+}
+
+// NewAnimeRelation devolve a aresta e a inversa (as duas devem ser gravadas juntas).
+func NewAnimeRelation(idAnime, idRelated int64, t AnimeRelationType, now time.Time) ([2]AnimeRelation, error) {
+	if idAnime == idRelated || idAnime <= 0 || idRelated <= 0 || !t.IsValid() {
+		return [2]AnimeRelation{}, ErrInvalidRelation
+	}
+	return [2]AnimeRelation{
+		{IDAnime: idAnime, IDAnimeRelated: idRelated, Type: t, CreatedAt: now},
+		{IDAnime: idRelated, IDAnimeRelated: idAnime, Type: t.Inverse(), CreatedAt: now},
+	}, nil
+}
+
+// ==========================================================
+// 2. FRANQUIA (Agrupamento)
+// ==========================================================
+
+// Franchise: Agrupa todas as obras de um mesmo universo ("Naruto" + "Naruto Shippuden" + filmes).
+// Os contadores são a soma dos animes da franquia (RollUp), nunca editados à mão.
+type Franchise struct {
+	ID int64 `json:"id" db:"id" gorm:"primaryKey"`
+
+	// Slug: Igual ao slug da Keyword CategoryWork (ex: "naruto"), para a busca convergir.
+	Slug string            `json:"slug" db:"slug" gorm:"uniqueIndex"`
+	Name map[string]string `json:"name" db:"name" gorm:"serializer:json"`
+
+	ImageCoverURL string `json:"image_cover_url" db:"image_cover_url"`
+
+	// DENORMALIZAÇÃO (Roll-up dos Animes)
+	AnimesCount         uint64 `json:"animes_count" db:"animes_count"`
+	PacksCount          uint64 `json:"packs_count" db:"packs_count"`
+	StickersCount       uint64 `json:"stickers_count" db:"stickers_count"`
+	PacksDownloadsCount uint64 `json:"packs_downloads_count" db:"packs_downloads_count"`
+	PacksLikesCount     uint64 `json:"packs_likes_count" db:"packs_likes_count"`
+
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// RollUp recalcula os contadores a partir dos animes da franquia.
+// Animes ocultos, moderados ou mesclados não entram na soma.
+func (f *Franchise) RollUp(animes []Anime, now time.Time) {
+	f.AnimesCount, f.PacksCount, f.StickersCount = 0, 0, 0
+	f.PacksDownloadsCount, f.PacksLikesCount = 0, 0
+
+	for i := range animes {
+		a := &animes[i]
+		if a.IDFranchise == nil || *a.IDFranchise != f.ID {
+			continue
+		}
+		if !a.IsVisible || a.IsModerated || a.MergedIntoID != nil {
+			continue
+		}
+		f.AnimesCount++
+		f.PacksCount += a.PacksCount
+		f.StickersCount += a.StickersCount
+		f.PacksDownloadsCount += a.PacksDownloadsCount
+		f.PacksLikesCount += a.PacksLikesCount
+	}
+	f.UpdatedAt = now
+}
+
+// GroupByRelations: Componentes conexos do grafo (Union-Find).
+// Sugere franquias para o Admin: cada grupo de animes ligados por relações vira um candidato.
+// Relações "alternative" também agrupam (remakes pertencem ao mesmo universo).
+func GroupByRelations(relations []AnimeRelation) [][]int64 {
+	parent := map[int64]int64{}
+	var find func(int64) int64
+	find = func(x int64) int64 {
+		if _, ok := parent[x]; !ok {
+			parent[x] = x
+		}
+		if parent[x] != x {
+			parent[x] = find(parent[x])
+		}
+		return parent[x]
+	}
+
+	for _, r := range relations {
+		a, b := find(r.IDAnime), find(r.IDAnimeRelated)
+		if a != b {
+			parent[a] = b
+		}
+	}
+
+	groups := map[int64][]int64{}
+	for id := range parent {
+		root := find(id)
+		groups[root] = append(groups[root], id)
+	}
+
+	out := make([][]int64, 0, len(groups))
+	for _, ids := range groups {
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+		out = append(out, ids)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i][0] < out[j][0] })
+	return out
+}
+
+// ==========================================================
+// 3. INPUTS E DTOs
+// ==========================================================
+
+type CreateAnimeRelationInput struct {
+	IDAnimeRelated int64             `json:"id_anime_related" binding:"required,gt=0"`
+	Type           AnimeRelationType `json:"type" binding:"required,oneof=sequel prequel side_story parent spin_off original movie series alternative"`
+}
+
+type CreateFranchiseInput struct {
+	Slug          string            `json:"slug" binding:"required,max=64"`
+	Name          map[string]string `json:"name" binding:"required"`
+	ImageCoverURL string            `json:"image_cover_url" binding:"omitempty,url"`
+	AnimeIDs      []int64           `json:"anime_ids" binding:"omitempty,dive,gt=0"`
+}
+
+type FranchiseResponse struct {
+	ID                  int64  `json:"id"`
+	Slug                string `json:"slug"`
+	Name                string `json:"name"`
+	ImageCoverURL       string `json:"image_cover_url"`
+	AnimesCount         uint64 `json:"animes_count"`
+	PacksCount          uint64 `json:"packs_count"`
+	StickersCount       uint64 `json:"stickers_count"`
+	PacksDownloadsCount uint64 `json:"packs_downloads_count"`
+	PacksLikesCount     uint64 `json:"packs_likes_count"`
+}
+
+// RelatedWorkResponse: Obra relacionada exibida na página do anime, com os packs dela.
+type RelatedWorkResponse struct {
+	Relation TranslatedResponse   `json:"relation"`
+	Anime    AnimeSummaryResponse `json:"anime"`
+	Packs    []PackResponse       `json:"packs"`
+}
+
+// Mapper (I18N)
+func (f *Franchise) ToResponse(lang constants.Language) FranchiseResponse {
+	return FranchiseResponse{
+		ID:                  f.ID,
+		Slug:                f.Slug,
+		Name:                lang.Get(f.Name),
+		ImageCoverURL:       f.ImageCoverURL,
+		AnimesCount:         f.AnimesCount,
+		PacksCount:          f.PacksCount,
+		StickersCount:       f.StickersCount,
+		PacksDownloadsCount: f.PacksDownloadsCount,
+		PacksLikesCount:     f.PacksLikesCount,
+	}
+}
+
+// BuildRelatedWorks monta o bloco "Obras Relacionadas" de um anime.
+// relations: arestas com IDAnime = anime da página. animes: animes relacionados (carregados em lote).
+// packsByAnime: packs públicos de cada relacionado, já mapeados (BuildPackResponses).
+// ceiling: ViewerMaxRating do usuário. Obras e packs acima do teto ficam de fora.
+func BuildRelatedWorks(relations []AnimeRelation, animes map[int64]Anime, packsByAnime map[int64][]PackResponse, lang constants.Language, ceiling ContentRating) []RelatedWorkResponse {
+	out := make([]RelatedWorkResponse, 0, len(relations))
+	for _, r := range relations {
+		a, ok := animes[r.IDAnimeRelated]
+		if !ok || !a.IsVisible || a.IsModerated || a.MergedIntoID != nil || !ceiling.Allows(a.ContentRating) {
+			continue
+		}
+		packs := make([]PackResponse, 0, len(packsByAnime[a.ID]))
+		for _, p := range packsByAnime[a.ID] {
+			if ceiling.Allows(p.ContentRating) {
+				packs = append(packs, p)
+			}
+		}
+		out = append(out, RelatedWorkResponse{
+			Relation: TranslatedResponse{Slug: string(r.Type), Name: r.Type.Translate(lang)},
+			Anime:    a.ToSummaryResponse(lang),
+			Packs:    packs,
+		})
+	}
+
+	// Ordem cronológica da franquia: prelúdios, principal, continuações...
+	sort.SliceStable(out, func(i, j int) bool {
+		ai, aj := animes[out[i].Anime.ID].FirstAired, animes[out[j].Anime.ID].FirstAired
+		if ai == nil || aj == nil {
+			return aj == nil && ai != nil
+		}
+		return ai.Before(*aj)
+	})
+	return out
+}
+
+/*
+REGRAS DE FRANQUIA E RELAÇÕES:
+1.  Grafo: Relações são gravadas nas duas direções (NewAnimeRelation), com o tipo inverso (Sequel <-> Prequel, Spin-off <-> Original, Filme <-> Série). A inversa sempre volta ao tipo original.
+2.  Franquia: Um anime pertence a no máximo uma franquia (Anime.IDFranchise). Sugestões vêm de GroupByRelations.
+3.  Contadores: PacksCount/StickersCount da franquia são a soma dos animes visíveis (RollUp), recalculada por Job.
+4.  Busca: O slug da franquia é o mesmo da Keyword CategoryWork, então buscar "naruto" encontra packs de todas as obras.
+5.  Página do Anime: Lista as obras relacionadas em ordem cronológica, cada uma com seus packs públicos. Obras e packs acima do teto do usuário ficam de fora.
+6.  Visibilidade: Obras ocultas, moderadas ou mescladas não aparecem nas relações nem somam na franquia.
+*/
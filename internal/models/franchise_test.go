@@ -0,0 +1,149 @@
+package models
+
+import (
+	"errors"
+	"otamaker-api/internal/constants"
+	"testing"
+	"time"
+)
+
+func TestRelationInverse(t *testing.T) {
+	for rel := range inverseRelation {
+		if got := rel.Inverse().Inverse(); got != rel {
+			t.Errorf("Inverse(Inverse(%q)) = %q", rel, got)
+		}
+		if relationTranslations[rel] == nil {
+			t.Errorf("%q sem tradução", rel)
+		}
+	}
+}
+
+func TestNewAnimeRelation(t *testing.T) {
+	now := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name        string
+		from, to    int64
+		rel         AnimeRelationType
+		wantErr     error
+		wantInverse AnimeRelationType
+	}{
+		{"continuação", 1, 2, RelationSequel, nil, RelationPrequel},
+		{"filme", 1, 3, RelationMovie, nil, RelationSeries},
+		{"alternativa é simétrica", 1, 4, RelationAlternative, nil, RelationAlternative},
+		{"laço", 1, 1, RelationSequel, ErrInvalidRelation, ""},
+		{"ID inválido", 0, 2, RelationSequel, ErrInvalidRelation, ""},
+		{"tipo desconhecido", 1, 2, "cameo", ErrInvalidRelation, ""},
+	}
+	for _, tc := range cases {
+		pair, err := NewAnimeRelation(tc.from, tc.to, tc.rel, now)
+		if !errors.Is(err, tc.wantErr) {
+			t.Errorf("%s: err = %v, want %v", tc.name, err, tc.wantErr)
+			continue
+		}
+		if tc.wantErr != nil {
+			continue
+		}
+		if pair[1].IDAnime != tc.to || pair[1].IDAnimeRelated != tc.from || pair[1].Type != tc.wantInverse {
+			t.Errorf("%s: inversa = %+v", tc.name, pair[1])
+		}
+	}
+}
+
+func TestFranchiseRollUp(t *testing.T) {
+	fid, other, merged := int64(1), int64(2), int64(9)
+	animes := []Anime{
+		{ID: 1, IDFranchise: &fid, IsVisible: true, PacksCount: 3, StickersCount: 10, PacksLikesCount: 4},
+		{ID: 2, IDFranchise: &fid, IsVisible: true, PacksCount: 2, StickersCount: 5, PacksDownloadsCount: 7},
+		{ID: 3, IDFranchise: &fid, IsVisible: false, PacksCount: 50},
+		{ID: 4, IDFranchise: &fid, IsVisible: true, MergedIntoID: &merged, PacksCount: 50},
+		{ID: 5, IDFranchise: &other, IsVisible: true, PacksCount: 50},
+		{ID: 6, IsVisible: true, PacksCount: 50},
+	}
+	f := &Franchise{ID: fid, PacksCount: 999}
+	f.RollUp(animes, time.Now())
+	if f.AnimesCount != 2 || f.PacksCount != 5 || f.StickersCount != 15 || f.PacksDownloadsCount != 7 || f.PacksLikesCount != 4 {
+		t.Errorf("franquia = %+v", f)
+	}
+}
+
+func TestGroupByRelations(t *testing.T) {
+	rel := func(a, b int64) AnimeRelation { return AnimeRelation{IDAnime: a, IDAnimeRelated: b} }
+	cases := []struct {
+		name      string
+		relations []AnimeRelation
+		want      [][]int64
+	}{
+		{"vazio", nil, [][]int64{}},
+		{"dois grupos", []AnimeRelation{rel(1, 2), rel(2, 1), rel(3, 4), rel(2, 5)}, [][]int64{{1, 2, 5}, {3, 4}}},
+		{"cadeia", []AnimeRelation{rel(7, 8), rel(8, 9), rel(9, 6)}, [][]int64{{6, 7, 8, 9}}},
+	}
+	for _, tc := range cases {
+		got := GroupByRelations(tc.relations)
+		if len(got) != len(tc.want) {
+			t.Errorf("%s: %v, want %v", tc.name, got, tc.want)
+			continue
+		}
+		for i := range tc.want {
+			if len(got[i]) != len(tc.want[i]) {
+				t.Errorf("%s: %v, want %v", tc.name, got, tc.want)
+				break
+			}
+			for j := range tc.want[i] {
+				if got[i][j] != tc.want[i][j] {
+					t.Errorf("%s: %v, want %v", tc.name, got, tc.want)
+				}
+			}
+		}
+	}
+}
+
+func TestBuildRelatedWorks(t *testing.T) {
+	date := func(y int) *time.Time {
+		v := time.Date(y, 1, 1, 0, 0, 0, 0, time.UTC)
+		return &v
+	}
+	merged := int64(1)
+	animes := map[int64]Anime{
+		2: {ID: 2, IsVisible: true, FirstAired: date(2010)},
+		3: {ID: 3, IsVisible: true, FirstAired: date(2002)},
+		4: {ID: 4, IsVisible: true, ContentRating: RatingAdult, FirstAired: date(2005)},
+		5: {ID: 5, IsVisible: true, MergedIntoID: &merged},
+		6: {ID: 6, IsVisible: true},
+	}
+	relations := []AnimeRelation{
+		{IDAnime: 1, IDAnimeRelated: 2, Type: RelationSequel},
+		{IDAnime: 1, IDAnimeRelated: 3, Type: RelationPrequel},
+		{IDAnime: 1, IDAnimeRelated: 4, Type: RelationSpinOff},
+		{IDAnime: 1, IDAnimeRelated: 5, Type: RelationMovie},
+		{IDAnime: 1, IDAnimeRelated: 6, Type: RelationAlternative},
+		{IDAnime: 1, IDAnimeRelated: 7, Type: RelationMovie}, // Não carregado.
+	}
+	packs := map[int64][]PackResponse{2: {{ID: 20}, {ID: 21, ContentRating: RatingSuggestive}}}
+	cases := []struct {
+		name      string
+		ceiling   ContentRating
+		wantOrder []int64
+		wantPacks int
+	}{
+		{"anônimo", RatingSafe, []int64{3, 2, 6}, 1},
+		{"adulto", RatingAdult, []int64{3, 4, 2, 6}, 2},
+	}
+	for _, tc := range cases {
+		out := BuildRelatedWorks(relations, animes, packs, constants.PT_BR, tc.ceiling)
+		if len(out) != len(tc.wantOrder) {
+			t.Errorf("%s: %d obras, want %v", tc.name, len(out), tc.wantOrder)
+			continue
+		}
+		for i, id := range tc.wantOrder {
+			if out[i].Anime.ID != id {
+				t.Errorf("%s: out[%d] = %d, want %d", tc.name, i, out[i].Anime.ID, id)
+			}
+			if id == 2 && len(out[i].Packs) != tc.wantPacks {
+				t.Errorf("%s: packs = %d, want %d", tc.name, len(out[i].Packs), tc.wantPacks)
+			}
+		}
+		if out[0].Relation.Name != "Prelúdio" {
+			t.Errorf("%s: relação = %q", tc.name, out[0].Relation.Name)
+		}
+	}
+}
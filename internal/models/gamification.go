@@ -76,6 +76,9 @@ type Badge struct {
 	// Regra Automática
 	RequirementType  string `json:"req_type" db:"req_type"`   // Ex: "packs_created_count"
 	RequirementValue int    `json:"req_value" db:"req_value"` // Ex: 100
+	// RequirementKeywordID: Keyword alvo dos tipos por keyword (ex: "one_piece"). Ver badge_keyword.go.
+	// Guarda o ID (não o slug): renomear a keyword não quebra o requisito e o merge re-aponta pelo ID.
+	RequirementKeywordID *int64 `json:"req_keyword_id" db:"req_keyword_id" gorm:"index"`
 }
 
 // Insignia: Medalha especial concedida MANUALMENTE ou por EVENTOS.
@@ -164,5 +167,5 @@ REGRAS DE GAMIFICAÇÃO:
 4.  O Fator "Fanático":
     - Para reconhecer um usuário como "Fã de One Piece", o sistema monitora a tabela 'MakerKeyword' (definida em keyword.go).
     - Se o usuário cria/baixa muito conteúdo com a tag "one_piece", o peso dele nessa keyword sobe.
-    - Isso pode desbloquear Badges específicas ou Insígnias customizadas no futuro.
+    - Isso desbloqueia Badges específicas ("keyword_weight" e "character_stickers", ver badge_keyword.go) ou Insígnias customizadas.
 */
@@ -1,6 +1,10 @@
 package models
 
 import (
+	"errors"
+	"otamaker-api/internal/constants"
+	"sort"
+	"strings"
 	"time"
 )
 
@@ -14,7 +18,7 @@ type KeywordCategory string
 
 const (
 	CategoryGeneral   KeywordCategory = "general"   // Ex: engraçado, meme, reação
-	CategoryCharacter KeywordCategory = "character" // Ex: naruto, goku, luffy
+	CategoryCharacter KeywordCategory = "character" // Ex: naruto, goku, luffy (espelho de Character)
 	CategoryWork      KeywordCategory = "work"      // Ex: one_piece (Nome da Obra/Franquia)
 	CategoryArtist    KeywordCategory = "artist"    // Ex: pixel_art, watercolor (Estilo visual)
 	CategoryEmotion   KeywordCategory = "emotion"   // Ex: sad, happy, angry
@@ -33,8 +37,10 @@ type Keyword struct {
 	// Todas as buscas em qualquer idioma convergem para este slug.
 	Slug string `json:"slug" db:"slug" gorm:"uniqueIndex"`
 
-	// Nome de Exibição (Padrão).
-	Name string `json:"name" db:"name"`
+	// Nome de Exibição (I18N) - mesmo padrão de Anime.Name.
+	// Ex: {"pt_br": "Chorando", "en_us": "Crying"}. Resolvido via Language.Get; o Slug continua em inglês.
+	// Era texto simples: a migração 0002 converte os nomes antigos para {"pt_br": nome}.
+	Name map[string]string `json:"name" db:"name" gorm:"serializer:json"`
 
 	// Categoria (Vital para Filtros).
 	// Permite buscas do tipo: "Mostre-me todos os PERSONAGENS (CategoryCharacter)".
@@ -49,6 +55,10 @@ type Keyword struct {
 	// Flexibilidade para guardar cor da tag na UI, ícone, link wiki, etc.
 	Meta map[string]string `json:"meta" db:"meta" gorm:"serializer:json"`
 
+	// IsPending: Criada automaticamente a partir de um termo desconhecido (keyword_resolver.go).
+	// Aguarda curadoria (aprovar, traduzir ou mesclar em outra keyword).
+	IsPending bool `json:"is_pending" db:"is_pending" gorm:"index"`
+
 	// Métricas de Relevância
 	// UsageCount: Popularidade de uso por Makers.
 	// SearchCount: Tendência de busca por Usuários.
@@ -70,9 +80,9 @@ type Keyword struct {
 type MakerKeyword struct {
 	IDMaker   int64 `json:"id_maker" db:"id_maker" gorm:"primaryKey"`
 	IDKeyword int64 `json:"id_keyword" db:"id_keyword" gorm:"primaryKey"`
-	
-	// Peso (0-100). Relevância do maker neste tema.
-	Weight    int       `json:"weight" db:"weight"` 
+
+	// Peso (0-100). Relevância do maker neste tema. Calculado pelo Job (ver expertise.go).
+	Weight    int       `json:"weight" db:"weight"`
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
 }
 
@@ -98,4 +108,144 @@ type StickerKeyword struct {
 	IDSticker int64     `json:"id_sticker" db:"id_sticker" gorm:"primaryKey"`
 	IDKeyword int64     `json:"id_keyword" db:"id_keyword" gorm:"primaryKey"`
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
-}
\ No newline at end of file
+}
+
+// =================================================================
+// 3. DTOs (Chips de Tag e Resultados de Busca)
+// =================================================================
+
+// KeywordResponse: Tag exibida no idioma do usuário. O slug continua sendo o valor de filtro.
+type KeywordResponse struct {
+	Slug     string          `json:"slug"`
+	Name     string          `json:"name"`
+	Category KeywordCategory `json:"category"`
+}
+
+// Mapper (I18N)
+// Sem nenhuma tradução, exibe o slug legível ("naruto_uzumaki" -> "naruto uzumaki").
+func (k *Keyword) ToResponse(lang constants.Language) KeywordResponse {
+	name := lang.Get(k.Name)
+	if name == "" {
+		name = strings.ReplaceAll(k.Slug, "_", " ")
+	}
+	return KeywordResponse{Slug: k.Slug, Name: name, Category: k.Category}
+}
+
+// KeywordChips converte o cache de slugs ('Sticker.Keywords', 'Pack.Keywords') em tags traduzidas.
+// Slugs sem keyword carregada saem com o nome legível do próprio slug.
+func KeywordChips(slugs []string, keywords map[string]Keyword, lang constants.Language) []KeywordResponse {
+	out := make([]KeywordResponse, 0, len(slugs))
+	for _, slug := range slugs {
+		k, ok := keywords[slug]
+		if !ok {
+			k = Keyword{Slug: slug, Category: CategoryGeneral}
+		}
+		out = append(out, k.ToResponse(lang))
+	}
+	return out
+}
+
+// =================================================================
+// 4. ADMIN (Tradução em Lote)
+// =================================================================
+
+var ErrUnsupportedLanguage = errors.New("idioma não suportado")
+
+// BulkKeywordTranslationInput: Traduções de várias keywords para um idioma.
+// Names: slug -> nome exibido. Nome vazio remove a tradução daquele idioma.
+type BulkKeywordTranslationInput struct {
+	Language string            `json:"language" binding:"required"`
+	Names    map[string]string `json:"names" binding:"required,min=1,max=500"`
+}
+
+// KeywordTranslationResult: Resumo devolvido ao Admin.
+type KeywordTranslationResult struct {
+	Language string   `json:"language"`
+	Updated  int      `json:"updated"`
+	Missing  []string `json:"missing"` // Slugs não encontrados.
+}
+
+// ApplyTranslations grava os nomes nas keywords carregadas (chave = slug).
+// Devolve as keywords alteradas para o Service persistir.
+func ApplyTranslations(keywords map[string]*Keyword, in BulkKeywordTranslationInput, now time.Time) ([]*Keyword, KeywordTranslationResult, error) {
+	if !constants.IsSupported(in.Language) {
+		return nil, KeywordTranslationResult{}, ErrUnsupportedLanguage
+	}
+	lang := string(constants.NormalizeLanguage(in.Language))
+	result := KeywordTranslationResult{Language: lang, Missing: []string{}}
+
+	var changed []*Keyword
+	for slug, name := range in.Names {
+		k, ok := keywords[slug]
+		if !ok {
+			result.Missing = append(result.Missing, slug)
+			continue
+		}
+		name = strings.TrimSpace(name)
+		if k.Name[lang] == name {
+			continue
+		}
+		if k.Name == nil {
+			k.Name = map[string]string{}
+		}
+		if name == "" {
+			delete(k.Name, lang)
+		} else {
+			k.Name[lang] = name
+		}
+		k.UpdatedAt = now
+		changed = append(changed, k)
+	}
+	sort.Strings(result.Missing)
+	result.Updated = len(changed)
+	return changed, result, nil
+}
+
+// IsSlug verifica o formato canônico de um slug: a-z, 0-9 e '_'.
+// Ex: "naruto_uzumaki" (válido), "Naruto Uzumaki" (inválido).
+func IsSlug(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, ch := range s {
+		if (ch < 'a' || ch > 'z') && (ch < '0' || ch > '9') && ch != '_' {
+			return false
+		}
+	}
+	return true
+}
+
+// Tabela de remoção de acentos (Latin-1 + comuns em PT/ES).
+var accentFold = map[rune]rune{
+	'á': 'a', 'à': 'a', 'â': 'a', 'ã': 'a', 'ä': 'a',
+	'é': 'e', 'è': 'e', 'ê': 'e', 'ë': 'e',
+	'í': 'i', 'ì': 'i', 'î': 'i', 'ï': 'i',
+	'ó': 'o', 'ò': 'o', 'ô': 'o', 'õ': 'o', 'ö': 'o',
+	'ú': 'u', 'ù': 'u', 'û': 'u', 'ü': 'u',
+	'ç': 'c', 'ñ': 'n', 'ý': 'y', 'ÿ': 'y',
+}
+
+// Slugify converte texto livre em slug canônico.
+// Ex: "Naruto Uzumaki" -> "naruto_uzumaki", "Coração" -> "coracao".
+// Caracteres fora de a-z/0-9 viram '_' (sem repetição nas pontas ou em sequência).
+func Slugify(input string) string {
+	var sb strings.Builder
+	sb.Grow(len(input))
+
+	pendingSep := false
+	for _, ch := range strings.ToLower(strings.TrimSpace(input)) {
+		if folded, ok := accentFold[ch]; ok {
+			ch = folded
+		}
+		if (ch >= 'a' && ch <= 'z') || (ch >= '0' && ch <= '9') {
+			if pendingSep && sb.Len() > 0 {
+				sb.WriteByte('_')
+			}
+			pendingSep = false
+			sb.WriteRune(ch)
+			continue
+		}
+		pendingSep = true
+	}
+	return sb.String()
+}
@@ -0,0 +1,403 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// ==========================================================
+// 1. INPUTS (Curadoria)
+// ==========================================================
+
+var (
+	ErrMergeSameKeyword      = errors.New("não é possível mesclar uma keyword nela mesma")
+	ErrMergeCharacterKeyword = errors.New("keyword espelho de personagem: edite o personagem")
+	ErrAliasTaken            = errors.New("alias já pertence a outra keyword")
+	ErrSplitEmpty            = errors.New("nenhum vínculo selecionado para a nova keyword")
+	ErrSplitSlugEmpty        = errors.New("slug inválido para a nova keyword: use letras ou números")
+)
+
+// MergeKeywordInput: A keyword da rota (origem) é absorvida por IDTarget.
+type MergeKeywordInput struct {
+	IDTarget int64 `json:"id_target" binding:"required,gt=0"`
+}
+
+// SplitKeywordInput: Cria uma keyword nova e move para ela os vínculos selecionados.
+// Ex: "kids" (crianças) -> "kids_anime" (demografia Kodomo) para alguns animes e packs.
+type SplitKeywordInput struct {
+	Slug     string            `json:"slug" binding:"required,max=64"`
+	Name     map[string]string `json:"name" binding:"required"`
+	Category KeywordCategory   `json:"category" binding:"required,oneof=general character work artist emotion event"`
+
+	StickerIDs  []int64  `json:"sticker_ids" binding:"omitempty,dive,gt=0"`
+	PackIDs     []int64  `json:"pack_ids" binding:"omitempty,dive,gt=0"`
+	AnimeIDs    []int64  `json:"anime_ids" binding:"omitempty,dive,gt=0"`
+	MoveAliases []string `json:"move_aliases"` // Aliases da origem que passam a pertencer à nova.
+}
+
+// UpdateKeywordAliasesInput: Inclusão e remoção pontual de aliases.
+type UpdateKeywordAliasesInput struct {
+	Add    []string `json:"add" binding:"omitempty,max=50,dive,min=1,max=64"`
+	Remove []string `json:"remove" binding:"omitempty,max=50"`
+}
+
+// KeywordCurationSet: Vínculos e caches carregados pelo Service (da origem e do destino).
+type KeywordCurationSet struct {
+	Makers   []MakerKeyword
+	Animes   []AnimeKeyword
+	Packs    []PackKeyword
+	Stickers []StickerKeyword
+
+	// Entidades cujo cache 'Keywords' contém o slug da origem.
+	StickerCache []*Sticker
+	PackCache    []*Pack
+	AnimeCache   []*Anime
+
+	// Badges por keyword cujo requisito aponta para a origem (RequirementKeywordID).
+	Badges []*Badge
+
+	// Baldes de busca da origem (KeywordSearchBucket), para a tendência seguir o destino.
+	SearchBuckets []KeywordSearchBucket
+}
+
+// KeywordCurationResult: O que o Service deve gravar (na mesma transação).
+// Pivots têm chave composta: mover = DELETE do antigo + INSERT do novo.
+type KeywordCurationResult struct {
+	DeleteMakers   []MakerKeyword
+	InsertMakers   []MakerKeyword
+	DeleteAnimes   []AnimeKeyword
+	InsertAnimes   []AnimeKeyword
+	DeletePacks    []PackKeyword
+	InsertPacks    []PackKeyword
+	DeleteStickers []StickerKeyword
+	InsertStickers []StickerKeyword
+
+	// Badges: Requisitos re-apontados para o destino (UPDATE).
+	Badges []*Badge
+
+	// Baldes de busca: Insert usa o upsert somador de KeywordSearchBucket (mesma hora no destino soma).
+	DeleteSearchBuckets []KeywordSearchBucket
+	InsertSearchBuckets []KeywordSearchBucket
+}
+
+// ==========================================================
+// 2. MERGE (A -> B)
+// ==========================================================
+
+// MergeKeywords absorve 'source' em 'target'.
+// Move os quatro pivots (sem duplicar), reescreve os caches 'Keywords' de Sticker/Pack/Anime,
+// acrescenta o slug, nomes e aliases da origem aos Aliases do destino e soma os contadores.
+// A origem deve ser apagada pelo Service ao final.
+func MergeKeywords(source, target *Keyword, set KeywordCurationSet, now time.Time) (KeywordCurationResult, error) {
+	var result KeywordCurationResult
+	if source.ID == target.ID {
+		return result, ErrMergeSameKeyword
+	}
+	if source.Category == CategoryCharacter {
+		return result, ErrMergeCharacterKeyword
+	}
+
+	// MakerKeyword: vínculo duplicado fica com o maior peso (o Job de pesos recalcula depois).
+	makerWeight := map[int64]*MakerKeyword{}
+	for i := range set.Makers {
+		if set.Makers[i].IDKeyword == target.ID {
+			makerWeight[set.Makers[i].IDMaker] = &set.Makers[i]
+		}
+	}
+	for _, mk := range set.Makers {
+		if mk.IDKeyword != source.ID {
+			continue
+		}
+		result.DeleteMakers = append(result.DeleteMakers, mk)
+		if existing, ok := makerWeight[mk.IDMaker]; ok {
+			if mk.Weight > existing.Weight {
+				existing.Weight = mk.Weight
+				result.InsertMakers = append(result.InsertMakers, *existing) // Upsert.
+			}
+			continue
+		}
+		mk.IDKeyword = target.ID
+		result.InsertMakers = append(result.InsertMakers, mk)
+	}
+
+	duplicates, n := 0, 0
+	result.DeleteAnimes, result.InsertAnimes, n = moveAnimeKeywords(set.Animes, source.ID, target.ID, nil)
+	duplicates += n
+	result.DeletePacks, result.InsertPacks, n = movePackKeywords(set.Packs, source.ID, target.ID, nil)
+	duplicates += n
+	result.DeleteStickers, result.InsertStickers, n = moveStickerKeywords(set.Stickers, source.ID, target.ID, nil)
+	duplicates += n
+
+	rewriteCaches(set, source.Slug, target.Slug, nil, now)
+
+	// Tendência: as buscas da origem passam a contar para o destino.
+	for _, b := range set.SearchBuckets {
+		if b.IDKeyword != source.ID {
+			continue
+		}
+		result.DeleteSearchBuckets = append(result.DeleteSearchBuckets, b)
+		b.IDKeyword = target.ID
+		result.InsertSearchBuckets = append(result.InsertSearchBuckets, b)
+	}
+
+	// Badges: o requisito segue a keyword, senão a badge para de ser concedida em silêncio.
+	for _, b := range set.Badges {
+		if b.RequirementKeywordID != nil && *b.RequirementKeywordID == source.ID {
+			id := target.ID
+			b.RequirementKeywordID = &id
+			result.Badges = append(result.Badges, b)
+		}
+	}
+
+	// Aliases: o slug antigo continua funcionando na busca e no resolvedor.
+	// O slug entra como está ("sad_face"); o resolvedor também indexa a forma com espaços.
+	target.Aliases = unionStrings(target.Aliases, []string{source.Slug})
+	target.Aliases = unionStrings(target.Aliases, source.Aliases)
+	for _, name := range source.Name {
+		target.Aliases = unionStrings(target.Aliases, []string{strings.ToLower(strings.TrimSpace(name))})
+	}
+	target.Name = fillMissing(target.Name, source.Name)
+
+	// Contadores: soma, descontando vínculos que existiam nas duas (viraram um só).
+	target.UsageCount += source.UsageCount
+	for i := 0; i < duplicates; i++ {
+		decrement(&target.UsageCount)
+	}
+	target.SearchCount += source.SearchCount
+	target.UpdatedAt = now
+	source.UsageCount, source.SearchCount = 0, 0
+
+	return result, nil
+}
+
+// ==========================================================
+// 3. SPLIT (A -> A + B)
+// ==========================================================
+
+// NewSplitKeyword monta a keyword nova do split (o Service grava antes de chamar SplitKeyword).
+// Slug que não gera nada após o Slugify (ex: só símbolos) é recusado.
+func NewSplitKeyword(in SplitKeywordInput, now time.Time) (Keyword, error) {
+	slug := Slugify(in.Slug)
+	if slug == "" {
+		return Keyword{}, ErrSplitSlugEmpty
+	}
+	return Keyword{
+		Slug:      slug,
+		Name:      in.Name,
+		Category:  in.Category,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// SplitKeyword move os vínculos selecionados de 'source' para 'target' (já gravada).
+// MakerKeyword não é movido: o Job de pesos recalcula a especialidade com os novos vínculos.
+func SplitKeyword(source, target *Keyword, in SplitKeywordInput, set KeywordCurationSet, now time.Time) (KeywordCurationResult, error) {
+	var result KeywordCurationResult
+	if source.ID == target.ID {
+		return result, ErrMergeSameKeyword
+	}
+	if len(in.StickerIDs)+len(in.PackIDs)+len(in.AnimeIDs) == 0 {
+		return result, ErrSplitEmpty
+	}
+
+	selected := map[string]map[int64]bool{
+		"sticker": toIDMap(in.StickerIDs),
+		"pack":    toIDMap(in.PackIDs),
+		"anime":   toIDMap(in.AnimeIDs),
+	}
+
+	duplicates, n := 0, 0
+	result.DeleteAnimes, result.InsertAnimes, n = moveAnimeKeywords(set.Animes, source.ID, target.ID, selected["anime"])
+	duplicates += n
+	result.DeletePacks, result.InsertPacks, n = movePackKeywords(set.Packs, source.ID, target.ID, selected["pack"])
+	duplicates += n
+	result.DeleteStickers, result.InsertStickers, n = moveStickerKeywords(set.Stickers, source.ID, target.ID, selected["sticker"])
+	duplicates += n
+
+	rewriteCaches(set, source.Slug, target.Slug, selected, now)
+
+	moved := uint64(len(result.DeleteAnimes) + len(result.DeletePacks) + len(result.DeleteStickers))
+	target.UsageCount += moved - uint64(duplicates)
+	for i := uint64(0); i < moved; i++ {
+		decrement(&source.UsageCount)
+	}
+
+	// Aliases escolhidos mudam de dono.
+	for _, alias := range in.MoveAliases {
+		alias = strings.ToLower(strings.TrimSpace(alias))
+		if containsString(source.Aliases, alias) {
+			source.Aliases = removeString(source.Aliases, alias)
+			target.Aliases = unionStrings(target.Aliases, []string{alias})
+		}
+	}
+	source.UpdatedAt, target.UpdatedAt = now, now
+	return result, nil
+}
+
+// ==========================================================
+// 4. ALIASES
+// ==========================================================
+
+// EditAliases inclui/remove aliases. Um alias não pode apontar para outra keyword (resolver carregado com todas).
+func (k *Keyword) EditAliases(in UpdateKeywordAliasesInput, resolver *KeywordResolver, now time.Time) error {
+	for _, raw := range in.Add {
+		alias := strings.ToLower(strings.TrimSpace(raw))
+		if alias == "" {
+			continue
+		}
+		if other, ok := resolver.Lookup(alias); ok && other.ID != k.ID && !other.IsPending {
+			return ErrAliasTaken
+		}
+		k.Aliases = unionStrings(k.Aliases, []string{alias})
+	}
+	for _, raw := range in.Remove {
+		k.Aliases = removeString(k.Aliases, strings.ToLower(strings.TrimSpace(raw)))
+	}
+	k.UpdatedAt = now
+	return nil
+}
+
+// ==========================================================
+// 5. AUXILIARES
+// ==========================================================
+
+// only: nil = todos os vínculos da origem (merge); senão, apenas os IDs selecionados (split).
+// duplicates: vínculos que já existiam no destino (apenas removidos da origem).
+func moveAnimeKeywords(rows []AnimeKeyword, from, to int64, only map[int64]bool) (del, ins []AnimeKeyword, duplicates int) {
+	has := map[int64]bool{}
+	for _, r := range rows {
+		if r.IDKeyword == to {
+			has[r.IDAnime] = true
+		}
+	}
+	for _, r := range rows {
+		if r.IDKeyword != from || (only != nil && !only[r.IDAnime]) {
+			continue
+		}
+		del = append(del, r)
+		if has[r.IDAnime] {
+			duplicates++
+			continue
+		}
+		r.IDKeyword = to
+		ins = append(ins, r)
+	}
+	return del, ins, duplicates
+}
+
+func movePackKeywords(rows []PackKeyword, from, to int64, only map[int64]bool) (del, ins []PackKeyword, duplicates int) {
+	has := map[int64]bool{}
+	for _, r := range rows {
+		if r.IDKeyword == to {
+			has[r.IDPack] = true
+		}
+	}
+	for _, r := range rows {
+		if r.IDKeyword != from || (only != nil && !only[r.IDPack]) {
+			continue
+		}
+		del = append(del, r)
+		if has[r.IDPack] {
+			duplicates++
+			continue
+		}
+		r.IDKeyword = to
+		ins = append(ins, r)
+	}
+	return del, ins, duplicates
+}
+
+func moveStickerKeywords(rows []StickerKeyword, from, to int64, only map[int64]bool) (del, ins []StickerKeyword, duplicates int) {
+	has := map[int64]bool{}
+	for _, r := range rows {
+		if r.IDKeyword == to {
+			has[r.IDSticker] = true
+		}
+	}
+	for _, r := range rows {
+		if r.IDKeyword != from || (only != nil && !only[r.IDSticker]) {
+			continue
+		}
+		del = append(del, r)
+		if has[r.IDSticker] {
+			duplicates++
+			continue
+		}
+		r.IDKeyword = to
+		ins = append(ins, r)
+	}
+	return del, ins, duplicates
+}
+
+// rewriteCaches troca o slug nos arrays 'Keywords' (sem duplicar). selected nil = todos.
+func rewriteCaches(set KeywordCurationSet, from, to string, selected map[string]map[int64]bool, now time.Time) {
+	pick := func(kind string, id int64) bool {
+		return selected == nil || selected[kind][id]
+	}
+	for _, s := range set.StickerCache {
+		if pick("sticker", s.ID) && containsString(s.Keywords, from) {
+			s.Keywords = replaceSlug(s.Keywords, from, to)
+			s.UpdatedAt = now
+		}
+	}
+	for _, p := range set.PackCache {
+		if pick("pack", p.ID) && containsString(p.Keywords, from) {
+			p.Keywords = replaceSlug(p.Keywords, from, to)
+			p.TouchContent(now)
+		}
+	}
+	for _, a := range set.AnimeCache {
+		if pick("anime", a.ID) && containsString(a.Keywords, from) {
+			a.Keywords = replaceSlug(a.Keywords, from, to)
+			a.UpdatedAt = &now
+		}
+	}
+}
+
+func replaceSlug(list []string, from, to string) []string {
+	out := make([]string, 0, len(list))
+	for _, s := range list {
+		if s == from {
+			s = to
+		}
+		if !containsString(out, s) {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// removeString devolve uma cópia sem 's' (não reaproveita o array de 'list', que pode ser compartilhado).
+func removeString(list []string, s string) []string {
+	out := make([]string, 0, len(list))
+	for _, item := range list {
+		if item != s {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+func toIDMap(ids []int64) map[int64]bool {
+	m := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		m[id] = true
+	}
+	return m
+}
+
+/*
+REGRAS DE CURADORIA DE KEYWORDS:
+1.  Merge: A origem é absorvida pelo destino. MakerKeyword, AnimeKeyword, PackKeyword e StickerKeyword são movidos sem duplicar.
+2.  Caches: Os arrays 'Keywords' de Sticker, Pack e Anime trocam o slug antigo pelo novo na mesma transação.
+3.  Aliases: O slug antigo (com '_'), os nomes e os aliases da origem viram Aliases do destino (o termo antigo continua encontrando).
+4.  Contadores: UsageCount e SearchCount são somados; vínculos que existiam nas duas keywords contam uma vez só.
+5.  Especialidade: Em conflito de MakerKeyword, fica o maior peso até o próximo recálculo do Job.
+6.  Personagens: Keywords espelho de Character não são mescladas por aqui (o slug é mantido pelo personagem).
+7.  Split: Cria uma keyword nova (slug obrigatório após o Slugify) e move apenas os vínculos selecionados (e os aliases escolhidos). A origem continua existindo.
+8.  Aliases Únicos: Um alias não pode apontar para duas keywords curadas.
+9.  Badges: No merge, as badges por keyword que exigiam a origem passam a exigir o destino (KeywordCurationResult.Badges).
+10. Tendência: No merge, os baldes de busca da origem (KeywordSearchBucket) são movidos para o destino e somados por hora.
+*/
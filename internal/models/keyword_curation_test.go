@@ -0,0 +1,159 @@
+package models
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestMergeKeywordsRejects(t *testing.T) {
+	cases := []struct {
+		name    string
+		source  Keyword
+		target  Keyword
+		wantErr error
+	}{
+		{"mesma keyword", Keyword{ID: 1}, Keyword{ID: 1}, ErrMergeSameKeyword},
+		{"espelho de personagem", Keyword{ID: 1, Category: CategoryCharacter}, Keyword{ID: 2}, ErrMergeCharacterKeyword},
+	}
+	for _, tc := range cases {
+		if _, err := MergeKeywords(&tc.source, &tc.target, KeywordCurationSet{}, time.Now()); !errors.Is(err, tc.wantErr) {
+			t.Errorf("%s: err = %v, want %v", tc.name, err, tc.wantErr)
+		}
+	}
+}
+
+func TestMergeKeywords(t *testing.T) {
+	now := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+	hour := SearchHour(now)
+	source := &Keyword{ID: 1, Slug: "sad_face", Name: map[string]string{"pt_br": "Tristinho"}, Aliases: []string{"triste"}, UsageCount: 3, SearchCount: 7}
+	target := &Keyword{ID: 2, Slug: "sad", Name: map[string]string{"en_us": "Sad"}, UsageCount: 5, SearchCount: 1}
+	required := int64(1)
+	badge := &Badge{ID: 9, RequirementKeywordID: &required}
+	sticker := &Sticker{ID: 30, Keywords: []string{"sad_face", "sad"}}
+	set := KeywordCurationSet{
+		Makers:        []MakerKeyword{{IDMaker: 7, IDKeyword: 1, Weight: 5}, {IDMaker: 7, IDKeyword: 2, Weight: 2}, {IDMaker: 8, IDKeyword: 1, Weight: 1}},
+		Packs:         []PackKeyword{{IDPack: 20, IDKeyword: 1}},
+		Stickers:      []StickerKeyword{{IDSticker: 30, IDKeyword: 1}, {IDSticker: 30, IDKeyword: 2}},
+		StickerCache:  []*Sticker{sticker},
+		Badges:        []*Badge{badge},
+		SearchBuckets: []KeywordSearchBucket{{IDKeyword: 1, Hour: hour, Count: 4}, {IDKeyword: 2, Hour: hour, Count: 9}},
+	}
+
+	result, err := MergeKeywords(source, target, set, now)
+	if err != nil {
+		t.Fatalf("err = %v", err)
+	}
+	checks := []struct {
+		name string
+		ok   bool
+	}{
+		{"makers movidos com o maior peso", len(result.DeleteMakers) == 2 && len(result.InsertMakers) == 2 && result.InsertMakers[0].Weight == 5},
+		{"pivot de sticker duplicado descartado", len(result.DeleteStickers) == 1 && len(result.InsertStickers) == 0},
+		{"pack movido", len(result.InsertPacks) == 1 && result.InsertPacks[0].IDKeyword == 2},
+		{"cache sem duplicar", len(sticker.Keywords) == 1 && sticker.Keywords[0] == "sad"},
+		{"badge re-apontada", len(result.Badges) == 1 && *badge.RequirementKeywordID == 2 && required == 1},
+		{"baldes movidos", len(result.DeleteSearchBuckets) == 1 && len(result.InsertSearchBuckets) == 1 &&
+			result.InsertSearchBuckets[0].IDKeyword == 2 && result.InsertSearchBuckets[0].Count == 4},
+		{"slug antigo com underscore", containsString(target.Aliases, "sad_face") && !containsString(target.Aliases, "sad face")},
+		{"aliases e nomes herdados", containsString(target.Aliases, "triste") && containsString(target.Aliases, "tristinho") && target.Name["pt_br"] == "Tristinho"},
+		{"contadores somados", target.UsageCount == 7 && target.SearchCount == 8 && source.UsageCount == 0},
+	}
+	for _, c := range checks {
+		if !c.ok {
+			t.Errorf("%s", c.name)
+		}
+	}
+	if k, ok := NewKeywordResolver([]Keyword{*target}).Lookup("Sad Face"); !ok || k.ID != 2 {
+		t.Errorf("termo antigo não resolve para o destino: %+v", k)
+	}
+}
+
+func TestNewSplitKeyword(t *testing.T) {
+	now := time.Now()
+	cases := []struct {
+		name     string
+		slug     string
+		wantSlug string
+		wantErr  error
+	}{
+		{"normalizado", "Kids Anime", "kids_anime", nil},
+		{"só símbolos", "!!!", "", ErrSplitSlugEmpty},
+		{"só kanji", "子供", "", ErrSplitSlugEmpty},
+	}
+	for _, tc := range cases {
+		k, err := NewSplitKeyword(SplitKeywordInput{Slug: tc.slug, Category: CategoryGeneral}, now)
+		if !errors.Is(err, tc.wantErr) || k.Slug != tc.wantSlug {
+			t.Errorf("%s: slug = %q, err = %v", tc.name, k.Slug, err)
+		}
+	}
+}
+
+func TestSplitKeyword(t *testing.T) {
+	now := time.Now()
+	source := &Keyword{ID: 1, Slug: "kids", Aliases: []string{"kodomo", "criancas"}, UsageCount: 4}
+	target := &Keyword{ID: 2, Slug: "kids_anime"}
+	anime := &Anime{ID: 10, Keywords: []string{"kids"}}
+	other := &Anime{ID: 11, Keywords: []string{"kids"}}
+	set := KeywordCurationSet{
+		Animes:     []AnimeKeyword{{IDAnime: 10, IDKeyword: 1}, {IDAnime: 11, IDKeyword: 1}},
+		Stickers:   []StickerKeyword{{IDSticker: 30, IDKeyword: 1}},
+		AnimeCache: []*Anime{anime, other},
+	}
+	cases := []struct {
+		name    string
+		in      SplitKeywordInput
+		wantErr error
+	}{
+		{"nada selecionado", SplitKeywordInput{}, ErrSplitEmpty},
+		{"anime selecionado", SplitKeywordInput{AnimeIDs: []int64{10}, MoveAliases: []string{"Kodomo"}}, nil},
+	}
+	for _, tc := range cases {
+		result, err := SplitKeyword(source, target, tc.in, set, now)
+		if !errors.Is(err, tc.wantErr) {
+			t.Errorf("%s: err = %v, want %v", tc.name, err, tc.wantErr)
+			continue
+		}
+		if tc.wantErr != nil {
+			continue
+		}
+		if len(result.InsertAnimes) != 1 || result.InsertAnimes[0].IDAnime != 10 || len(result.DeleteStickers) != 0 {
+			t.Errorf("%s: result = %+v", tc.name, result)
+		}
+		if anime.Keywords[0] != "kids_anime" || other.Keywords[0] != "kids" {
+			t.Errorf("%s: caches = %v / %v", tc.name, anime.Keywords, other.Keywords)
+		}
+		if source.UsageCount != 3 || target.UsageCount != 1 {
+			t.Errorf("%s: UsageCount = %d/%d", tc.name, source.UsageCount, target.UsageCount)
+		}
+		if containsString(source.Aliases, "kodomo") || !containsString(target.Aliases, "kodomo") {
+			t.Errorf("%s: aliases = %v / %v", tc.name, source.Aliases, target.Aliases)
+		}
+	}
+}
+
+func TestEditAliases(t *testing.T) {
+	keywords := []Keyword{{ID: 1, Slug: "sad"}, {ID: 2, Slug: "happy", Aliases: []string{"feliz"}}, {ID: 3, Slug: "alegre", IsPending: true}}
+	resolver := NewKeywordResolver(keywords)
+	cases := []struct {
+		name    string
+		in      UpdateKeywordAliasesInput
+		wantErr error
+		want    []string
+	}{
+		{"alias de outra curada", UpdateKeywordAliasesInput{Add: []string{"Feliz"}}, ErrAliasTaken, nil},
+		{"alias de pendente", UpdateKeywordAliasesInput{Add: []string{" Alegre "}}, nil, []string{"alegre"}},
+		{"remover", UpdateKeywordAliasesInput{Add: []string{"triste"}, Remove: []string{"Triste"}}, nil, []string{}},
+	}
+	for _, tc := range cases {
+		k := &Keyword{ID: 1, Slug: "sad"}
+		err := k.EditAliases(tc.in, resolver, time.Now())
+		if !errors.Is(err, tc.wantErr) {
+			t.Errorf("%s: err = %v, want %v", tc.name, err, tc.wantErr)
+			continue
+		}
+		if tc.wantErr == nil && len(k.Aliases) != len(tc.want) {
+			t.Errorf("%s: aliases = %v, want %v", tc.name, k.Aliases, tc.want)
+		}
+	}
+}
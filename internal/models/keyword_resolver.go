@@ -0,0 +1,268 @@
+package models
+
+import (
+	"otamaker-api/internal/constants"
+	"strings"
+	"time"
+)
+
+// ==========================================================
+// 1. NORMALIZAÇÃO DE TERMOS LIVRES
+// ==========================================================
+
+// NormalizeTerm: Forma de comparação de um termo digitado.
+// Minúsculas, sem acentos e com espaços simples. Ex: " Coração  Partido " -> "coracao partido".
+func NormalizeTerm(term string) string {
+	var sb strings.Builder
+	sb.Grow(len(term))
+	for _, ch := range strings.ToLower(term) {
+		if folded, ok := accentFold[ch]; ok {
+			ch = folded
+		}
+		sb.WriteRune(ch)
+	}
+	return strings.Join(strings.Fields(sb.String()), " ")
+}
+
+// ==========================================================
+// 2. RESOLVEDOR (Termo -> Slug Canônico)
+// ==========================================================
+
+// KeywordStore: Persistência mínima que o resolvedor precisa.
+type KeywordStore interface {
+	// CreateKeyword grava uma keyword nova (pendente) e preenche o ID.
+	CreateKeyword(k *Keyword) error
+}
+
+// ResolvedKeyword: Resultado de um termo.
+type ResolvedKeyword struct {
+	IDKeyword int64           `json:"id_keyword"`
+	Slug      string          `json:"slug"`
+	Category  KeywordCategory `json:"category"`
+	IsPending bool            `json:"is_pending"`
+}
+
+// KeywordResolver: Índice em memória de Slug, Nome e Aliases (todos os idiomas) para a Keyword.
+// Montado por request (ou cacheado pelo Service) a partir das keywords carregadas.
+type KeywordResolver struct {
+	byTerm  map[string]*Keyword
+	byID    map[int64]*Keyword
+	touched map[int64]*Keyword
+}
+
+// NewKeywordResolver indexa as keywords em duas passadas: primeiro todos os slugs, depois nomes e aliases.
+// Assim o slug de uma keyword nunca é tomado pelo alias de outra, qualquer que seja a ordem de carga.
+// Nomes e aliases só ocupam termos livres; em conflito entre eles, a keyword curada vence a pendente.
+func NewKeywordResolver(keywords []Keyword) *KeywordResolver {
+	r := &KeywordResolver{
+		byTerm:  make(map[string]*Keyword, len(keywords)*4),
+		byID:    make(map[int64]*Keyword, len(keywords)),
+		touched: map[int64]*Keyword{},
+	}
+	for i := range keywords {
+		r.indexSlug(&keywords[i])
+	}
+	for i := range keywords {
+		r.indexTerms(&keywords[i])
+	}
+	return r
+}
+
+// index: Keyword criada depois da montagem (pendente do Resolve).
+func (r *KeywordResolver) index(k *Keyword) {
+	r.indexSlug(k)
+	r.indexTerms(k)
+}
+
+func (r *KeywordResolver) indexSlug(k *Keyword) {
+	r.byID[k.ID] = k
+	for _, key := range slugKeys(k.Slug) {
+		r.byTerm[key] = k
+	}
+}
+
+func (r *KeywordResolver) indexTerms(k *Keyword) {
+	terms := make([]string, 0, len(k.Name)+len(k.Aliases))
+	for _, name := range k.Name {
+		terms = append(terms, name)
+	}
+	for _, alias := range k.Aliases {
+		// Alias com '_' (slug antigo de um merge) também responde pela forma com espaços.
+		terms = append(terms, alias, strings.ReplaceAll(alias, "_", " "))
+	}
+	for _, t := range terms {
+		key := NormalizeTerm(t)
+		if key == "" {
+			continue
+		}
+		if current, ok := r.byTerm[key]; ok && (!current.IsPending || containsString(slugKeys(current.Slug), key)) {
+			continue
+		}
+		r.byTerm[key] = k
+	}
+}
+
+// slugKeys: Formas indexadas do slug ("naruto_uzumaki" e "naruto uzumaki").
+func slugKeys(slug string) []string {
+	keys := make([]string, 0, 2)
+	for _, t := range []string{slug, strings.ReplaceAll(slug, "_", " ")} {
+		if key := NormalizeTerm(t); key != "" && !containsString(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// Lookup procura um termo já conhecido (sem criar pendente).
+func (r *KeywordResolver) Lookup(term string) (*Keyword, bool) {
+	key := NormalizeTerm(term)
+	if k, ok := r.byTerm[key]; ok {
+		return k, true
+	}
+	// "Naruto Uzumaki" e "naruto_uzumaki" devem convergir.
+	k, ok := r.byTerm[NormalizeTerm(strings.ReplaceAll(Slugify(term), "_", " "))]
+	return k, ok
+}
+
+// Resolve converte termos livres em slugs canônicos, sem duplicar.
+// Termos desconhecidos viram Keywords pendentes (IsPending) para curadoria e já são usáveis.
+// Termos sem nenhuma letra ou número (ex: só emoji) são ignorados.
+// lang: idioma de quem digitou (nome inicial da pendente).
+func (r *KeywordResolver) Resolve(terms []string, lang constants.Language, store KeywordStore, now time.Time) ([]ResolvedKeyword, error) {
+	out := make([]ResolvedKeyword, 0, len(terms))
+	seen := map[string]bool{}
+	for _, term := range terms {
+		k, ok := r.Lookup(term)
+		if !ok {
+			slug := Slugify(term)
+			if slug == "" {
+				continue
+			}
+			k = &Keyword{
+				Slug:      slug,
+				Name:      map[string]string{string(lang): strings.TrimSpace(term)},
+				Category:  CategoryGeneral,
+				IsPending: true,
+				CreatedAt: now,
+				UpdatedAt: now,
+			}
+			if err := store.CreateKeyword(k); err != nil {
+				return nil, err
+			}
+			r.index(k)
+		}
+		if seen[k.Slug] {
+			continue
+		}
+		seen[k.Slug] = true
+		out = append(out, ResolvedKeyword{IDKeyword: k.ID, Slug: k.Slug, Category: k.Category, IsPending: k.IsPending})
+	}
+	return out, nil
+}
+
+// Touched: Keywords com UsageCount alterado (o Service persiste).
+func (r *KeywordResolver) Touched() []*Keyword {
+	out := make([]*Keyword, 0, len(r.touched))
+	for _, k := range r.touched {
+		out = append(out, k)
+	}
+	return out
+}
+
+func (r *KeywordResolver) addUsage(idKeyword int64, delta int) {
+	k, ok := r.byID[idKeyword]
+	if !ok {
+		return
+	}
+	if delta > 0 {
+		k.UsageCount++
+	} else {
+		decrement(&k.UsageCount)
+	}
+	r.touched[k.ID] = k
+}
+
+// ==========================================================
+// 3. PIVOTS E CACHE (Sticker / Pack)
+// ==========================================================
+
+// diffKeywordIDs: IDs novos e removidos entre a lista atual de pivots e a resolvida.
+func diffKeywordIDs(current []int64, resolved []ResolvedKeyword) (added, removed []int64) {
+	want := make(map[int64]bool, len(resolved))
+	for _, rk := range resolved {
+		want[rk.IDKeyword] = true
+	}
+	have := make(map[int64]bool, len(current))
+	for _, id := range current {
+		have[id] = true
+		if !want[id] {
+			removed = append(removed, id)
+		}
+	}
+	for _, rk := range resolved {
+		if !have[rk.IDKeyword] {
+			added = append(added, rk.IDKeyword)
+		}
+	}
+	return added, removed
+}
+
+func resolvedSlugs(resolved []ResolvedKeyword) []string {
+	slugs := make([]string, 0, len(resolved))
+	for _, rk := range resolved {
+		slugs = append(slugs, rk.Slug)
+	}
+	return slugs
+}
+
+// ApplyToSticker substitui as keywords do sticker: reescreve o cache 'Sticker.Keywords'
+// e devolve os pivots a inserir e a remover. UsageCount sobe/desce só na diferença.
+// Personagens marcados (StickerCharacter) devem entrar nos termos para o slug continuar no cache.
+func (r *KeywordResolver) ApplyToSticker(s *Sticker, resolved []ResolvedKeyword, current []StickerKeyword, now time.Time) (added, removed []StickerKeyword) {
+	ids := make([]int64, 0, len(current))
+	for _, sk := range current {
+		ids = append(ids, sk.IDKeyword)
+	}
+	plus, minus := diffKeywordIDs(ids, resolved)
+	for _, id := range plus {
+		added = append(added, StickerKeyword{IDSticker: s.ID, IDKeyword: id, CreatedAt: now})
+		r.addUsage(id, 1)
+	}
+	for _, id := range minus {
+		removed = append(removed, StickerKeyword{IDSticker: s.ID, IDKeyword: id})
+		r.addUsage(id, -1)
+	}
+	s.Keywords = resolvedSlugs(resolved)
+	s.UpdatedAt = now
+	return added, removed
+}
+
+// ApplyToPack: Mesmo contrato de ApplyToSticker para 'Pack.Keywords' e PackKeyword.
+func (r *KeywordResolver) ApplyToPack(p *Pack, resolved []ResolvedKeyword, current []PackKeyword, now time.Time) (added, removed []PackKeyword) {
+	ids := make([]int64, 0, len(current))
+	for _, pk := range current {
+		ids = append(ids, pk.IDKeyword)
+	}
+	plus, minus := diffKeywordIDs(ids, resolved)
+	for _, id := range plus {
+		added = append(added, PackKeyword{IDPack: p.ID, IDKeyword: id, CreatedAt: now})
+		r.addUsage(id, 1)
+	}
+	for _, id := range minus {
+		removed = append(removed, PackKeyword{IDPack: p.ID, IDKeyword: id})
+		r.addUsage(id, -1)
+	}
+	p.Keywords = resolvedSlugs(resolved)
+	p.TouchContent(now)
+	return added, removed
+}
+
+/*
+REGRAS DO RESOLVEDOR DE KEYWORDS:
+1.  Normalização: Termos são comparados em minúsculas, sem acentos e com espaços simples ("Coração" = "coracao").
+2.  Convergência: Slug, Nomes traduzidos e Aliases de qualquer idioma levam ao mesmo slug canônico ("Chorando" -> "crying").
+3.  Desconhecidos: Viram Keyword pendente (IsPending, CategoryGeneral) para curadoria. O slug já é usado normalmente.
+4.  Conflito: O slug de uma keyword sempre vence nomes/aliases de outras (indexado antes). Entre aliases, a curada vence a pendente.
+5.  Pivots: StickerKeyword/PackKeyword são sincronizados pela diferença; o cache 'Keywords' guarda os slugs resolvidos.
+6.  Popularidade: 'UsageCount' sobe a cada novo vínculo e desce quando o vínculo é removido.
+*/
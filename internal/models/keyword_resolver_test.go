@@ -0,0 +1,108 @@
+package models
+
+import (
+	"otamaker-api/internal/constants"
+	"testing"
+	"time"
+)
+
+// memKeywordStore: KeywordStore em memória (IDs sequenciais a partir de 1000).
+type memKeywordStore struct {
+	created []*Keyword
+}
+
+func (m *memKeywordStore) CreateKeyword(k *Keyword) error {
+	k.ID = int64(1000 + len(m.created))
+	m.created = append(m.created, k)
+	return nil
+}
+
+func TestNormalizeTerm(t *testing.T) {
+	cases := []struct {
+		input string
+		want  string
+	}{
+		{" Coração  Partido ", "coracao partido"},
+		{"CHORANDO", "chorando"},
+		{"", ""},
+	}
+	for _, tc := range cases {
+		if got := NormalizeTerm(tc.input); got != tc.want {
+			t.Errorf("NormalizeTerm(%q) = %q, want %q", tc.input, got, tc.want)
+		}
+	}
+}
+
+func TestKeywordResolverPrecedence(t *testing.T) {
+	sad := Keyword{ID: 1, Slug: "sad", Aliases: []string{"crying"}}
+	crying := Keyword{ID: 2, Slug: "crying", Name: map[string]string{"pt_br": "Chorando"}}
+	pending := Keyword{ID: 3, Slug: "chorao", Aliases: []string{"chorando"}, IsPending: true}
+	curated := Keyword{ID: 4, Slug: "tears", Aliases: []string{"lagrimas"}}
+	pendingAlias := Keyword{ID: 5, Slug: "lagrima", Aliases: []string{"lagrimas"}, IsPending: true}
+	cases := []struct {
+		name     string
+		keywords []Keyword
+		term     string
+		wantID   int64
+	}{
+		{"slug vence alias carregado antes", []Keyword{sad, crying}, "crying", 2},
+		{"slug vence alias carregado depois", []Keyword{crying, sad}, "crying", 2},
+		{"nome de outra keyword", []Keyword{crying, sad}, "Chorando", 2},
+		{"curada vence pendente", []Keyword{pending, crying}, "chorando", 2},
+		{"alias curado vence pendente", []Keyword{pendingAlias, curated}, "lágrimas", 4},
+		{"slug com espaços", []Keyword{sad, crying}, "Sad", 1},
+	}
+	for _, tc := range cases {
+		r := NewKeywordResolver(tc.keywords)
+		k, ok := r.Lookup(tc.term)
+		if !ok || k.ID != tc.wantID {
+			t.Errorf("%s: Lookup(%q) = %+v, want ID %d", tc.name, tc.term, k, tc.wantID)
+		}
+	}
+}
+
+func TestKeywordResolverResolve(t *testing.T) {
+	now := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	store := &memKeywordStore{}
+	r := NewKeywordResolver([]Keyword{{ID: 1, Slug: "crying", Name: map[string]string{"pt_br": "Chorando"}}, {ID: 2, Slug: "naruto_uzumaki"}})
+
+	out, err := r.Resolve([]string{"Chorando", "crying", "Naruto Uzumaki", "Dattebayo", "😭", "dattebayo"}, constants.PT_BR, store, now)
+	if err != nil {
+		t.Fatalf("err = %v", err)
+	}
+	want := []string{"crying", "naruto_uzumaki", "dattebayo"}
+	if len(out) != len(want) {
+		t.Fatalf("resolvidos = %+v, want %v", out, want)
+	}
+	for i, slug := range want {
+		if out[i].Slug != slug {
+			t.Errorf("out[%d] = %q, want %q", i, out[i].Slug, slug)
+		}
+	}
+	if len(store.created) != 1 || !out[2].IsPending || store.created[0].Name["pt_br"] != "Dattebayo" {
+		t.Errorf("pendentes = %+v", store.created)
+	}
+}
+
+func TestApplyToSticker(t *testing.T) {
+	now := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	keywords := []Keyword{{ID: 1, Slug: "crying", UsageCount: 3}, {ID: 2, Slug: "happy", UsageCount: 1}, {ID: 3, Slug: "angry"}}
+	r := NewKeywordResolver(keywords)
+	s := &Sticker{ID: 7, Keywords: []string{"crying", "happy"}}
+	current := []StickerKeyword{{IDSticker: 7, IDKeyword: 1}, {IDSticker: 7, IDKeyword: 2}}
+	resolved := []ResolvedKeyword{{IDKeyword: 1, Slug: "crying"}, {IDKeyword: 3, Slug: "angry"}}
+
+	added, removed := r.ApplyToSticker(s, resolved, current, now)
+	if len(added) != 1 || added[0].IDKeyword != 3 || len(removed) != 1 || removed[0].IDKeyword != 2 {
+		t.Errorf("added = %+v, removed = %+v", added, removed)
+	}
+	wantUsage := map[int64]uint64{1: 3, 2: 0, 3: 1}
+	for _, k := range keywords {
+		if k.UsageCount != wantUsage[k.ID] {
+			t.Errorf("UsageCount[%s] = %d, want %d", k.Slug, k.UsageCount, wantUsage[k.ID])
+		}
+	}
+	if len(s.Keywords) != 2 || s.Keywords[1] != "angry" || len(r.Touched()) != 2 {
+		t.Errorf("Keywords = %v, tocadas = %d", s.Keywords, len(r.Touched()))
+	}
+}
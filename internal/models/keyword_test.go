@@ -0,0 +1,107 @@
+package models
+
+import (
+	"errors"
+	"otamaker-api/internal/constants"
+	"testing"
+	"time"
+)
+
+func TestKeywordChips(t *testing.T) {
+	keywords := map[string]Keyword{
+		"crying":         {Slug: "crying", Name: map[string]string{"pt_br": "Chorando", "en_us": "Crying"}, Category: CategoryGeneral},
+		"naruto_uzumaki": {Slug: "naruto_uzumaki", Category: CategoryCharacter},
+	}
+	cases := []struct {
+		lang constants.Language
+		want []string
+	}{
+		{constants.PT_BR, []string{"Chorando", "naruto uzumaki", "sem keyword"}},
+		{constants.EN_US, []string{"Crying", "naruto uzumaki", "sem keyword"}},
+	}
+	for _, tc := range cases {
+		chips := KeywordChips([]string{"crying", "naruto_uzumaki", "sem_keyword"}, keywords, tc.lang)
+		if len(chips) != len(tc.want) {
+			t.Fatalf("%s: %d chips", tc.lang, len(chips))
+		}
+		for i, name := range tc.want {
+			if chips[i].Name != name {
+				t.Errorf("%s: chips[%d] = %q, want %q", tc.lang, i, chips[i].Name, name)
+			}
+		}
+		if chips[1].Category != CategoryCharacter || chips[2].Slug != "sem_keyword" {
+			t.Errorf("%s: chips = %+v", tc.lang, chips)
+		}
+	}
+}
+
+func TestApplyTranslations(t *testing.T) {
+	now := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name        string
+		in          BulkKeywordTranslationInput
+		wantErr     error
+		wantUpdated int
+		wantMissing []string
+		check       func(k map[string]*Keyword) bool
+	}{
+		{"idioma desconhecido", BulkKeywordTranslationInput{Language: "fr", Names: map[string]string{"crying": "Pleurer"}}, ErrUnsupportedLanguage, 0, nil, nil},
+		{"formato do cliente", BulkKeywordTranslationInput{Language: "en-US", Names: map[string]string{"crying": " Crying ", "zzz": "x", "aaa": "y"}},
+			nil, 1, []string{"aaa", "zzz"}, func(k map[string]*Keyword) bool { return k["crying"].Name["en_us"] == "Crying" }},
+		{"vazio remove", BulkKeywordTranslationInput{Language: "pt", Names: map[string]string{"crying": ""}},
+			nil, 1, []string{}, func(k map[string]*Keyword) bool { _, ok := k["crying"].Name["pt_br"]; return !ok }},
+		{"sem mudança", BulkKeywordTranslationInput{Language: "pt_br", Names: map[string]string{"crying": "Chorando"}},
+			nil, 0, []string{}, nil},
+		{"keyword sem nomes", BulkKeywordTranslationInput{Language: "es", Names: map[string]string{"happy": "Feliz"}},
+			nil, 1, []string{}, func(k map[string]*Keyword) bool { return k["happy"].Name["es_es"] == "Feliz" }},
+	}
+	for _, tc := range cases {
+		keywords := map[string]*Keyword{
+			"crying": {Slug: "crying", Name: map[string]string{"pt_br": "Chorando"}},
+			"happy":  {Slug: "happy"},
+		}
+		changed, result, err := ApplyTranslations(keywords, tc.in, now)
+		if !errors.Is(err, tc.wantErr) {
+			t.Errorf("%s: err = %v, want %v", tc.name, err, tc.wantErr)
+			continue
+		}
+		if tc.wantErr != nil {
+			continue
+		}
+		if len(changed) != tc.wantUpdated || result.Updated != tc.wantUpdated {
+			t.Errorf("%s: alteradas = %d, want %d", tc.name, len(changed), tc.wantUpdated)
+		}
+		if len(result.Missing) != len(tc.wantMissing) {
+			t.Errorf("%s: Missing = %v, want %v", tc.name, result.Missing, tc.wantMissing)
+		}
+		for i := range tc.wantMissing {
+			if result.Missing[i] != tc.wantMissing[i] {
+				t.Errorf("%s: Missing = %v, want %v", tc.name, result.Missing, tc.wantMissing)
+			}
+		}
+		if tc.check != nil && !tc.check(keywords) {
+			t.Errorf("%s: keywords = %+v", tc.name, keywords)
+		}
+	}
+}
+
+func TestSlugify(t *testing.T) {
+	cases := []struct {
+		input  string
+		want   string
+		isSlug bool
+	}{
+		{"Naruto Uzumaki", "naruto_uzumaki", true},
+		{"  Coração -- Partido!! ", "coracao_partido", true},
+		{"進撃の巨人", "", false},
+	}
+	for _, tc := range cases {
+		got := Slugify(tc.input)
+		if got != tc.want {
+			t.Errorf("Slugify(%q) = %q, want %q", tc.input, got, tc.want)
+		}
+		if IsSlug(got) != tc.isSlug {
+			t.Errorf("IsSlug(%q) = %v", got, IsSlug(got))
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package models
+
+import "otamaker-api/internal/constants"
+
+// ==========================================================
+// 1. CARREGAMENTO EM LOTE (Anti N+1)
+// ==========================================================
+
+// ResponseLoader: Contrato que o repositório implementa para montar respostas em lote.
+// Cada método deve fazer UMA consulta (WHERE id IN (...)), nunca uma por item.
+// IDs ausentes no mapa de retorno são tratados como "não encontrado".
+type ResponseLoader interface {
+	// LoadMakers já devolve o DTO público (Real + Artificial, Rank e Styles resolvidos).
+	LoadMakers(ids []int64) (map[int64]MakerPublicResponse, error)
+	LoadAnimes(ids []int64) (map[int64]Anime, error)
+	LoadPacks(ids []int64) (map[int64]Pack, error)
+	// LoadKeywords: Chave = Slug (para traduzir os chips de tag).
+	LoadKeywords(slugs []string) (map[string]Keyword, error)
+}
+
+// idSet: Acumula IDs únicos (> 0) preservando a ordem de chegada.
+type idSet struct {
+	seen map[int64]bool
+	list []int64
+}
+
+func newIDSet(capacity int) *idSet {
+	return &idSet{seen: make(map[int64]bool, capacity), list: make([]int64, 0, capacity)}
+}
+
+func (s *idSet) add(id int64) {
+	if id > 0 && !s.seen[id] {
+		s.seen[id] = true
+		s.list = append(s.list, id)
+	}
+}
+
+// slugSet: Mesmo contrato de idSet para slugs (ignora vazios).
+type slugSet struct {
+	seen map[string]bool
+	list []string
+}
+
+func newSlugSet(capacity int) *slugSet {
+	return &slugSet{seen: make(map[string]bool, capacity), list: make([]string, 0, capacity)}
+}
+
+func (s *slugSet) add(slugs ...string) {
+	for _, slug := range slugs {
+		if slug != "" && !s.seen[slug] {
+			s.seen[slug] = true
+			s.list = append(s.list, slug)
+		}
+	}
+}
+
+// BuildPackResponses monta os PackResponse com Owner, Anime, RemixedFrom e Tags embutidos.
+// Custo fixo de no máximo 4 consultas, independente do tamanho da lista:
+// packs de origem (remix) -> makers (donos + donos das origens) -> animes -> keywords.
+func BuildPackResponses(packs []Pack, lang constants.Language, loader ResponseLoader) ([]PackResponse, error) {
+	sources := newIDSet(len(packs))
+	animes := newIDSet(len(packs))
+	for i := range packs {
+		animes.add(packs[i].IDAnime)
+		if packs[i].IDPackSource != nil {
+			sources.add(*packs[i].IDPackSource)
+		}
+	}
+
+	sourcePacks := map[int64]Pack{}
+	if len(sources.list) > 0 {
+		var err error
+		if sourcePacks, err = loader.LoadPacks(sources.list); err != nil {
+			return nil, err
+		}
+	}
+
+	makers := newIDSet(len(packs) + len(sourcePacks))
+	for i := range packs {
+		makers.add(packs[i].IDMaker)
+	}
+	for _, src := range sourcePacks {
+		makers.add(src.IDMaker)
+	}
+
+	makerMap, err := loader.LoadMakers(makers.list)
+	if err != nil {
+		return nil, err
+	}
+
+	animeMap := map[int64]Anime{}
+	if len(animes.list) > 0 {
+		if animeMap, err = loader.LoadAnimes(animes.list); err != nil {
+			return nil, err
+		}
+	}
+
+	slugs := newSlugSet(len(packs) * 4)
+	for i := range packs {
+		slugs.add(packs[i].Keywords...)
+	}
+	keywordMap := map[string]Keyword{}
+	if len(slugs.list) > 0 {
+		if keywordMap, err = loader.LoadKeywords(slugs.list); err != nil {
+			return nil, err
+		}
+	}
+
+	out := make([]PackResponse, 0, len(packs))
+	for i := range packs {
+		p := &packs[i]
+		resp := p.ToResponse()
+		resp.Tags = KeywordChips(p.Keywords, keywordMap, lang)
+
+		if owner, ok := makerMap[p.IDMaker]; ok {
+			resp.Owner = &owner
+		}
+
+		// Anime oculto/moderado não é embutido (Regra 16 do Anime).
+		if a, ok := animeMap[p.IDAnime]; ok && a.IsVisible && !a.IsModerated {
+			summary := a.ToSummaryResponse(lang)
+			resp.Anime = &summary
+		}
+
+		if p.IDPackSource != nil {
+			if src, ok := sourcePacks[*p.IDPackSource]; ok {
+				remix := src.ToRemixResponse(nil)
+				if srcOwner, ok := makerMap[src.IDMaker]; ok {
+					remix.MakerNickname = srcOwner.Nickname
+				}
+				resp.RemixedFrom = &remix
+			}
+		}
+
+		out = append(out, resp)
+	}
+	return out, nil
+}
+
+// WithStickerTags preenche 'Tags' de stickers já mapeados (ex: saída de StickersForViewer) em 1 consulta.
+func WithStickerTags(responses []StickerResponse, lang constants.Language, loader ResponseLoader) error {
+	slugs := newSlugSet(len(responses) * 4)
+	for i := range responses {
+		slugs.add(responses[i].Keywords...)
+	}
+	keywordMap := map[string]Keyword{}
+	if len(slugs.list) > 0 {
+		var err error
+		if keywordMap, err = loader.LoadKeywords(slugs.list); err != nil {
+			return err
+		}
+	}
+	for i := range responses {
+		responses[i].Tags = KeywordChips(responses[i].Keywords, keywordMap, lang)
+	}
+	return nil
+}
+
+// BuildAnimeResponses: Mapeamento em lote (sem consultas extras, apenas I18N).
+func BuildAnimeResponses(animes []Anime, lang constants.Language) []AnimeResponse {
+	out := make([]AnimeResponse, 0, len(animes))
+	for i := range animes {
+		out = append(out, animes[i].ToResponse(lang))
+	}
+	return out
+}
+
+/*
+REGRAS DE RESPOSTA PÚBLICA:
+1.  I18N: Todo texto multilíngue (Anime.Name, Synopsis, Genres, Season) é resolvido no idioma do cliente via Language.Get.
+2.  Anti N+1: Listas de packs são montadas com BuildPackResponses. Proibido carregar Maker/Anime dentro de loop.
+3.  Identidade: O dono é sempre exposto como MakerPublicResponse (nunca Account ou Maker cru).
+4.  Slugs: Genres e Season retornam o slug (para filtros) e o texto traduzido (para exibição).
+5.  Hemisfério: O rótulo da temporada segue a estação local dos meses dela (Winter Jan-Mar no Sul = "Verão/Outono").
+    O slug continua o do calendário de exibição e 'BroadcastName' traz o nome da indústria.
+6.  Tags: 'Keywords' continua com os slugs (inglês). 'Tags' traz os mesmos slugs com o nome no idioma do cliente (KeywordChips).
+*/
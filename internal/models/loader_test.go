@@ -0,0 +1,157 @@
+package models
+
+import (
+	"otamaker-api/internal/constants"
+	"testing"
+)
+
+// fakeLoader: ResponseLoader em memória que conta as consultas.
+type fakeLoader struct {
+	makers   map[int64]MakerPublicResponse
+	animes   map[int64]Anime
+	packs    map[int64]Pack
+	keywords map[string]Keyword
+	calls    map[string]int
+}
+
+func (f *fakeLoader) LoadMakers(ids []int64) (map[int64]MakerPublicResponse, error) {
+	f.calls["makers"]++
+	out := map[int64]MakerPublicResponse{}
+	for _, id := range ids {
+		if m, ok := f.makers[id]; ok {
+			out[id] = m
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeLoader) LoadAnimes(ids []int64) (map[int64]Anime, error) {
+	f.calls["animes"]++
+	out := map[int64]Anime{}
+	for _, id := range ids {
+		if a, ok := f.animes[id]; ok {
+			out[id] = a
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeLoader) LoadPacks(ids []int64) (map[int64]Pack, error) {
+	f.calls["packs"]++
+	out := map[int64]Pack{}
+	for _, id := range ids {
+		if p, ok := f.packs[id]; ok {
+			out[id] = p
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeLoader) LoadKeywords(slugs []string) (map[string]Keyword, error) {
+	f.calls["keywords"]++
+	out := map[string]Keyword{}
+	for _, slug := range slugs {
+		if k, ok := f.keywords[slug]; ok {
+			out[slug] = k
+		}
+	}
+	return out, nil
+}
+
+func newFakeLoader() *fakeLoader {
+	return &fakeLoader{
+		makers: map[int64]MakerPublicResponse{1: {ID: 1, Nickname: "dono"}, 2: {ID: 2, Nickname: "autor"}},
+		animes: map[int64]Anime{
+			10: {ID: 10, IsVisible: true, Name: map[string]string{"pt_br": "Naruto"}},
+			11: {ID: 11, IsVisible: true, IsModerated: true, Name: map[string]string{"pt_br": "Banido"}},
+		},
+		packs:    map[int64]Pack{50: {ID: 50, IDMaker: 2, Name: "Origem", Status: PackPublished}},
+		keywords: map[string]Keyword{"crying": {Slug: "crying", Name: map[string]string{"pt_br": "Chorando"}}},
+		calls:    map[string]int{},
+	}
+}
+
+func TestBuildPackResponses(t *testing.T) {
+	src := int64(50)
+	missing := int64(99)
+	cases := []struct {
+		name      string
+		pack      Pack
+		wantOwner string
+		wantAnime string
+		wantRemix string
+		wantTag   string
+		wantCalls map[string]int
+	}{
+		{"completo", Pack{ID: 1, IDMaker: 1, IDAnime: 10, IDPackSource: &src, Keywords: []string{"crying"}},
+			"dono", "Naruto", "autor", "Chorando", map[string]int{"packs": 1, "makers": 1, "animes": 1, "keywords": 1}},
+		{"anime moderado não é embutido", Pack{ID: 2, IDMaker: 1, IDAnime: 11},
+			"dono", "", "", "", map[string]int{"makers": 1, "animes": 1}},
+		{"origem ausente e slug sem keyword", Pack{ID: 3, IDMaker: 3, IDPackSource: &missing, Keywords: []string{"big_smile"}},
+			"", "", "", "big smile", map[string]int{"packs": 1, "makers": 1, "keywords": 1}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			loader := newFakeLoader()
+			out, err := BuildPackResponses([]Pack{tc.pack}, constants.PT_BR, loader)
+			if err != nil {
+				t.Fatalf("err = %v", err)
+			}
+			resp := out[0]
+			gotOwner := ""
+			if resp.Owner != nil {
+				gotOwner = resp.Owner.Nickname
+			}
+			if gotOwner != tc.wantOwner {
+				t.Errorf("Owner = %q, want %q", gotOwner, tc.wantOwner)
+			}
+			if (resp.Anime == nil) != (tc.wantAnime == "") || (resp.Anime != nil && resp.Anime.Name != tc.wantAnime) {
+				t.Errorf("Anime = %+v, want %q", resp.Anime, tc.wantAnime)
+			}
+			if (resp.RemixedFrom == nil) != (tc.wantRemix == "") || (resp.RemixedFrom != nil && resp.RemixedFrom.MakerNickname != tc.wantRemix) {
+				t.Errorf("RemixedFrom = %+v, want %q", resp.RemixedFrom, tc.wantRemix)
+			}
+			if tc.wantTag != "" && (len(resp.Tags) != 1 || resp.Tags[0].Name != tc.wantTag) {
+				t.Errorf("Tags = %+v, want %q", resp.Tags, tc.wantTag)
+			}
+			for _, kind := range []string{"packs", "makers", "animes", "keywords"} {
+				if loader.calls[kind] != tc.wantCalls[kind] {
+					t.Errorf("consultas de %s = %d, want %d", kind, loader.calls[kind], tc.wantCalls[kind])
+				}
+			}
+		})
+	}
+}
+
+func TestBuildPackResponsesBatches(t *testing.T) {
+	loader := newFakeLoader()
+	packs := make([]Pack, 20)
+	for i := range packs {
+		packs[i] = Pack{ID: int64(i + 1), IDMaker: 1, IDAnime: 10, Keywords: []string{"crying"}}
+	}
+	if _, err := BuildPackResponses(packs, constants.PT_BR, loader); err != nil {
+		t.Fatalf("err = %v", err)
+	}
+	for kind, n := range loader.calls {
+		if n > 1 {
+			t.Errorf("consultas de %s = %d, want no máximo 1", kind, n)
+		}
+	}
+}
+
+func TestWithStickerTags(t *testing.T) {
+	loader := newFakeLoader()
+	responses := []StickerResponse{{Keywords: []string{"crying"}}, {Keywords: []string{"crying", "big_smile"}}, {}}
+	if err := WithStickerTags(responses, constants.PT_BR, loader); err != nil {
+		t.Fatalf("err = %v", err)
+	}
+	if loader.calls["keywords"] != 1 {
+		t.Errorf("consultas = %d, want 1", loader.calls["keywords"])
+	}
+	if len(responses[1].Tags) != 2 || responses[1].Tags[0].Name != "Chorando" || responses[1].Tags[1].Name != "big smile" {
+		t.Errorf("Tags = %+v", responses[1].Tags)
+	}
+	if responses[2].Tags == nil || len(responses[2].Tags) != 0 {
+		t.Errorf("sticker sem keywords deve ter Tags vazio, got %+v", responses[2].Tags)
+	}
+}
@@ -123,6 +123,8 @@ type MakerSettings struct {
 	// Preferências
 	ShowAdultContent bool `json:"show_adult_content" db:"show_adult_content"`
 	AllowDirectMsg   bool `json:"allow_direct_msg" db:"allow_direct_msg"`
+	// Spoilers não liberados: "blur" (padrão) ou "hide". O progresso por anime fica em MakerAnimeSpoiler.
+	SpoilerMode SpoilerMode `json:"spoiler_mode" db:"spoiler_mode"`
 
 	// Notificações
 	NotifyOnLike    bool `json:"notify_on_like" db:"notify_on_like"`
@@ -212,6 +214,9 @@ type MakerPublicResponse struct {
 	// Visual
 	AvatarStyle      *StyleResponse `json:"avatar_style"` 
 	PackStyle        *StyleResponse `json:"pack_style"`
+
+	// Especialidades ("Fã de Naruto"). Preenchido pelo Service via TopExpertise (ver expertise.go).
+	Expertise        []ExpertiseTagResponse `json:"expertise,omitempty"`
 }
 
 // AdminUpdateMakerInput: Ferramenta de "Deus" para manipular o perfil.
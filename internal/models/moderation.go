@@ -44,7 +44,7 @@ const (
 	TargetPack    TargetType = 2 // Pacote inteiro (Capa, Nome)
 	TargetSticker TargetType = 3 // Imagem específica do Sticker
 	TargetAnime   TargetType = 4 // Dados do Anime (Sinopse, Capa)
-	TargetReview  TargetType = 5 // Comentários ou Avaliações
+	TargetReview  TargetType = 5 // Comentários ou Avaliações (PackReview)
 )
 
 // ReasonType: Motivo Detalhado da Denúncia
@@ -116,3 +116,9 @@ type ModerationResponse struct {
 	// Preview do item denunciado para facilitar a vida do Admin no Front
 	TargetPreview interface{} `json:"target_preview"`
 }
+
+// IsStrike: Denúncia procedente que gerou alguma ação contra o conteúdo/usuário.
+// Usado no histórico do criador (ex: Triage do Pack).
+func (m *Moderation) IsStrike() bool {
+	return m.Status == StatusResolved && m.ActionTaken != ActionNone
+}
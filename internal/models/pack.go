@@ -16,6 +16,8 @@ type Pack struct {
 	IDMaker int64 `json:"id_maker" db:"id_maker"`
 	// IDModerationBanned: Se preenchido, o pacote está banido globalmente.
 	IDModerationBanned *int64 `json:"id_moderation_banned" db:"id_moderation_banned"`
+	// IDPackSource: Pack de origem quando este é um remix (Linhagem, ver fork.go).
+	IDPackSource *int64 `json:"id_pack_source" db:"id_pack_source" gorm:"index"`
 
 	// DESCRIÇÃO E VISUAL
 	Name        string `json:"name" db:"name"`
@@ -30,14 +32,38 @@ type Pack struct {
 	// CONFIGURAÇÕES
 	IsDeleted  bool `json:"is_deleted" db:"is_deleted"`   // Soft Delete
 	IsAnimated bool `json:"is_animated" db:"is_animated"` // Define se o pacote contém animações.
-	IsFeatured bool `json:"is_featured" db:"is_featured"` // Destaque editorial.
-	IsVisible  bool `json:"is_visible" db:"is_visible"`   // Publicado ou Rascunho.
+	IsFeatured bool `json:"is_featured" db:"is_featured"` // Destaque editorial (controlado por FeatureSchedule).
+	IsVisible  bool `json:"is_visible" db:"is_visible"`   // Cache de Status == Publicado.
+
+	// SPOILER (ver spoiler.go)
+	IsSpoiler      bool   `json:"is_spoiler" db:"is_spoiler"`
+	SpoilerAnimeID *int64 `json:"spoiler_anime_id" db:"spoiler_anime_id"`
+	SpoilerEpisode *int16 `json:"spoiler_episode" db:"spoiler_episode"` // Null = obra inteira.
+
+	// CLASSIFICAÇÃO (ver content_rating.go)
+	// DeclaredRating: Informado pelo Maker. ContentRating: Efetivo (escalonado pelos stickers).
+	DeclaredRating ContentRating `json:"declared_rating" db:"declared_rating"`
+	ContentRating  ContentRating `json:"content_rating" db:"content_rating" gorm:"index"`
+
+	// CICLO DE VIDA (ver pack_lifecycle.go)
+	Status      PackStatus `json:"status" db:"status" gorm:"index"`
+	PublishedAt *time.Time `json:"published_at" db:"published_at"` // Primeira publicação.
 
 	// MONETIZAÇÃO E SCORE
 	Triage *float32 `json:"triage" db:"triage"` // Nota interna de qualidade.
 	Score  *float32 `json:"score" db:"score"`   // Média de avaliação dos usuários.
 	Price  *float64 `json:"price" db:"price"`   // Valor de venda (Null = Grátis).
 
+	// Base da média incremental (ver review.go).
+	ReviewsCount uint64 `json:"reviews_count" db:"reviews_count"`
+	RatingsSum   uint64 `json:"-" db:"ratings_sum"`
+
+	// Detalhamento da Triagem (Somente Admin, ver triage.go).
+	TriageBreakdown *TriageBreakdown `json:"-" db:"triage_breakdown" gorm:"serializer:json"`
+	TriagedAt       *time.Time       `json:"-" db:"triaged_at"`
+	// ContentUpdatedAt: Última edição de conteúdo (nome, tags, stickers). Mudança de status não conta.
+	ContentUpdatedAt *time.Time `json:"-" db:"content_updated_at"`
+
 	// MÉTRICAS E DADOS TÉCNICOS
 	StickersCount  uint64  `json:"total_stickers" db:"total_stickers"`
 	StickersSize   float64 `json:"stickers_size" db:"stickers_size"` // Validação de limite total do pacote.
@@ -46,6 +72,7 @@ type Pack struct {
 	LikesCount     uint64  `json:"likes_count" db:"likes_count"`
 	DownloadsCount uint64  `json:"downloads_count" db:"downloads_count"`
 	FavoritesCount uint64  `json:"favorites_count" db:"favorites_count"`
+	ForksCount     uint64  `json:"forks_count" db:"forks_count"`
 
 	CreatedAt         time.Time  `json:"created_at" db:"created_at"`
 	UpdatedAt         time.Time  `json:"updated_at" db:"updated_at"`
@@ -63,13 +90,18 @@ type Pack struct {
 type PackCreate struct {
 	IDAnime      int64    `json:"id_anime" binding:"required,gt=0"`
 	IsAnimated   bool     `json:"is_animated"`
-	IsVisible    bool     `json:"is_visible"`
 	Price        float64  `json:"price" binding:"omitempty,gte=0"`
 	TrayImageURL string   `json:"tray_image_url" binding:"required,url"`
 	Name         string   `json:"name" binding:"required,min=3,max=64"`
 	Description  string   `json:"description" binding:"omitempty,max=256"`
 	Keywords     []string `json:"keywords" binding:"omitempty,max=10,dive,max=32"`
 
+	// Marcação opcional de spoiler.
+	Spoiler *SpoilerInput `json:"spoiler"`
+
+	// Classificação declarada (Default: safe). O efetivo nunca fica abaixo do sticker mais adulto.
+	ContentRating ContentRating `json:"content_rating" binding:"omitempty,oneof=safe suggestive adult"`
+
 	// Lista inicial de Stickers. Mínimo 3 exigido pelo WA.
 	Stickers []int64 `json:"stickers" binding:"required,min=3,max=30,dive,gt=0"`
 }
@@ -88,8 +120,82 @@ type PackUpdate struct {
 	// Atualiza a lista completa de stickers do pacote.
 	Stickers *[]int64 `json:"updated_stickers" binding:"omitempty,min=3,max=30,dive,gt=0"`
 
-	IsVisible *bool    `json:"is_visible" binding:"omitempty"`
-	Price     *float64 `json:"price" binding:"omitempty,gte=0"`
+	Price *float64 `json:"price" binding:"omitempty,gte=0"`
+
+	ContentRating *ContentRating `json:"content_rating" binding:"omitempty,oneof=safe suggestive adult"`
+
+	// Marcação de spoiler (RemoveSpoiler=true desmarca).
+	Spoiler       *SpoilerInput `json:"spoiler"`
+	RemoveSpoiler bool          `json:"remove_spoiler"`
+}
+
+// PackStatusInput: Ações do ciclo de vida (publicar, despublicar, enviar para curadoria).
+// Substitui a edição direta de 'IsVisible'.
+type PackStatusInput struct {
+	Status PackStatus `json:"status" binding:"required,oneof=draft in_review published unpublished"`
+}
+
+// ==========================================================
+// 3. DTOs (Resposta Pública)
+// ==========================================================
+
+type PackResponse struct {
+	ID             int64             `json:"id"`
+	Name           string            `json:"name"`
+	Description    string            `json:"description"`
+	TrayImageURL   string            `json:"tray_image_url"`
+	Keywords       []string          `json:"keywords"`
+	Tags           []KeywordResponse `json:"tags"` // Keywords traduzidas (BuildPackResponses).
+	IsAnimated     bool              `json:"is_animated"`
+	IsFeatured     bool              `json:"is_featured"`
+	Score          *float32          `json:"score"`
+	ReviewsCount   uint64            `json:"reviews_count"`
+	Price          *float64          `json:"price"`
+	StickersCount  uint64            `json:"total_stickers"`
+	DataVersion    string            `json:"data_version"`
+	LikesCount     uint64            `json:"likes_count"`
+	DownloadsCount uint64            `json:"downloads_count"`
+	FavoritesCount uint64            `json:"favorites_count"`
+	ForksCount     uint64            `json:"forks_count"`
+
+	ContentRating ContentRating `json:"content_rating"` // Efetivo.
+	PublishedAt   *time.Time    `json:"published_at"`
+
+	// Embutidos (carregados em lote, ver loader.go)
+	Owner       *MakerPublicResponse  `json:"owner"`
+	Anime       *AnimeSummaryResponse `json:"anime"`        // Null = Pack original.
+	RemixedFrom *PackRemixResponse    `json:"remixed_from"` // Null = Pack autoral.
+	Spoiler     *SpoilerResponse      `json:"spoiler"`      // Preenchido por PacksForViewer.
+}
+
+// Mapper
+// Os embutidos (Owner, Anime, RemixedFrom) são preenchidos por BuildPackResponses.
+func (p *Pack) ToResponse() PackResponse {
+	keywords := p.Keywords
+	if keywords == nil {
+		keywords = []string{}
+	}
+
+	return PackResponse{
+		ID:             p.ID,
+		Name:           p.Name,
+		Description:    p.Description,
+		TrayImageURL:   p.TrayImageURL,
+		Keywords:       keywords,
+		IsAnimated:     p.IsAnimated,
+		IsFeatured:     p.IsFeatured,
+		Score:          p.Score,
+		ReviewsCount:   p.ReviewsCount,
+		Price:          p.Price,
+		StickersCount:  p.StickersCount,
+		DataVersion:    p.DataVersion,
+		LikesCount:     p.LikesCount,
+		DownloadsCount: p.DownloadsCount,
+		FavoritesCount: p.FavoritesCount,
+		ForksCount:     p.ForksCount,
+		ContentRating:  p.ContentRating.Normalize(),
+		PublishedAt:    p.PublishedAt,
+	}
 }
 
 /*
@@ -100,4 +206,8 @@ REGRAS DO PACOTE (PACK):
 4.  Metadados Técnicos: 'TrayImageURL' (96x96px) e 'DataVersion' são requisitos estritos para integração com APIs de mensageria (WhatsApp).
 5.  Interações: Likes e Favoritos são ações do Maker armazenadas nas tabelas dele. O Pack guarda apenas os totais.
 6.  Contexto: Um pacote deve tentar se vincular a um Anime (IDAnime), mas aceita vínculo genérico (ID=0) para conteúdos originais.
-*/
\ No newline at end of file
+7.  Avaliação: 'Score' é a média das PackReview (1 por Maker). Rankings usam a média bayesiana (review.go).
+8.  Publicação: Todo pack nasce Rascunho. Publicar/Despublicar segue a máquina de estados de pack_lifecycle.go.
+9.  Remix: Qualquer Maker pode remixar um pack publicado. A linhagem fica em 'IDPackSource' (fork.go).
+10. Classificação: O pack é no mínimo tão adulto quanto o seu sticker mais adulto (RecomputeRating, content_rating.go).
+*/
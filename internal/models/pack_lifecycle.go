@@ -0,0 +1,240 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ==========================================================
+// 1. CICLO DE VIDA (Rascunho -> Publicado)
+// ==========================================================
+
+// PackStatus: Estado editorial do pacote.
+type PackStatus string
+
+const (
+	PackDraft       PackStatus = "draft"       // Em edição, só o dono vê.
+	PackInReview    PackStatus = "in_review"   // Aguardando curadoria (Triage baixa).
+	PackPublished   PackStatus = "published"   // Público. Conta nos contadores.
+	PackUnpublished PackStatus = "unpublished" // Retirado do ar pelo dono. Pode voltar.
+)
+
+// TriageReviewThreshold: Abaixo desta nota (0-100), o pack passa pela curadoria antes de publicar.
+const TriageReviewThreshold float32 = 50
+
+// Limites de exportação (WhatsApp).
+const (
+	ExportMinStickers      = 3
+	ExportMaxStickers      = 30
+	ExportStickerSize      = 512    // px (quadrado)
+	ExportMaxStickerBytes  = 500000 // ~500KB
+	ExportMaxStickerEmojis = 3
+)
+
+var ErrInvalidTransition = errors.New("transição de status inválida")
+
+// packTransitions: Máquina de estados. Chave = estado atual, valor = destinos permitidos.
+var packTransitions = map[PackStatus]map[PackStatus]bool{
+	PackDraft:       {PackInReview: true, PackPublished: true},
+	PackInReview:    {PackDraft: true, PackPublished: true},
+	PackPublished:   {PackUnpublished: true},
+	PackUnpublished: {PackDraft: true, PackInReview: true, PackPublished: true},
+}
+
+// EffectiveStatus: Packs anteriores ao ciclo de vida têm Status vazio.
+// Até a migração (migrations/0001) rodar, o estado é deduzido do antigo 'IsVisible'.
+func (p *Pack) EffectiveStatus() PackStatus {
+	if p.Status != "" {
+		return p.Status
+	}
+	if p.IsVisible {
+		return PackPublished
+	}
+	return PackDraft
+}
+
+// IsPublished: Atalho usado por índices, listagens e contadores.
+func (p *Pack) IsPublished() bool {
+	return p.EffectiveStatus() == PackPublished
+}
+
+// CanTransition verifica se a mudança de estado é permitida.
+func (p *Pack) CanTransition(to PackStatus) bool {
+	return packTransitions[p.EffectiveStatus()][to]
+}
+
+// NeedsReview: Packs sem triagem (ou com triagem vencida/baixa) vão para curadoria.
+func (p *Pack) NeedsReview() bool {
+	return p.Triage == nil || p.TriageStale() || *p.Triage < TriageReviewThreshold
+}
+
+// SendToReview coloca o pack na fila de curadoria.
+func (p *Pack) SendToReview(now time.Time) error {
+	if !p.CanTransition(PackInReview) {
+		return fmt.Errorf("%w: %s -> %s", ErrInvalidTransition, p.EffectiveStatus(), PackInReview)
+	}
+	p.setStatus(PackInReview, now)
+	return nil
+}
+
+// RejectReview devolve o pack ao rascunho (curadoria reprovou).
+func (p *Pack) RejectReview(now time.Time) error {
+	if p.EffectiveStatus() != PackInReview {
+		return fmt.Errorf("%w: %s -> %s", ErrInvalidTransition, p.EffectiveStatus(), PackDraft)
+	}
+	p.setStatus(PackDraft, now)
+	return nil
+}
+
+// Publish valida o pack para exportação e incrementa os contadores uma única vez.
+// anime pode ser nil (Pack original, IDAnime = 0).
+// makerAlreadyInAnime: o dono já tem outro pack publicado neste anime (não conta de novo em MakersCount).
+// Se 'NeedsReview', o pack vai para PackInReview (sem contadores): o Service consulta 'p.Status' para avisar o Maker.
+// Vindo de PackInReview, a chamada é a aprovação da curadoria e publica direto.
+func (p *Pack) Publish(stickers []Sticker, maker *Maker, anime *Anime, makerAlreadyInAnime bool, now time.Time) error {
+	if !p.CanTransition(PackPublished) {
+		return fmt.Errorf("%w: %s -> %s", ErrInvalidTransition, p.EffectiveStatus(), PackPublished)
+	}
+	if err := ValidateForExport(p, stickers); err != nil {
+		return err
+	}
+	// Só depois da validação: um envio recusado não altera o pack.
+	p.RecomputeRating(stickers)
+	if p.EffectiveStatus() != PackInReview && p.NeedsReview() {
+		return p.SendToReview(now)
+	}
+
+	p.setStatus(PackPublished, now)
+	if p.PublishedAt == nil {
+		p.PublishedAt = &now
+	}
+
+	maker.PacksCreatedCount++
+	if anime != nil {
+		anime.PacksCount++
+		if !makerAlreadyInAnime {
+			anime.MakersCount++
+		}
+	}
+	return nil
+}
+
+// Unpublish retira o pack do ar e desfaz exatamente o que 'Publish' incrementou.
+// makerStillInAnime: o dono continua com outro pack publicado neste anime.
+func (p *Pack) Unpublish(maker *Maker, anime *Anime, makerStillInAnime bool, now time.Time) error {
+	if !p.CanTransition(PackUnpublished) {
+		return fmt.Errorf("%w: %s -> %s", ErrInvalidTransition, p.EffectiveStatus(), PackUnpublished)
+	}
+
+	p.setStatus(PackUnpublished, now)
+
+	decrement(&maker.PacksCreatedCount)
+	if anime != nil {
+		decrement(&anime.PacksCount)
+		if !makerStillInAnime {
+			decrement(&anime.MakersCount)
+		}
+	}
+	return nil
+}
+
+// setStatus mantém 'IsVisible' como cache de "Status == Publicado" (usado nos índices/queries).
+func (p *Pack) setStatus(status PackStatus, now time.Time) {
+	p.Status = status
+	p.IsVisible = status == PackPublished
+	p.UpdatedAt = now
+}
+
+// decrement evita underflow em contadores denormalizados.
+func decrement(counter *uint64) {
+	if *counter > 0 {
+		*counter--
+	}
+}
+
+// ==========================================================
+// 2. VALIDAÇÃO DE EXPORTAÇÃO (WhatsApp)
+// ==========================================================
+
+// ExportValidationError: Lista todos os problemas de uma vez para o Maker corrigir.
+type ExportValidationError struct {
+	Issues []string `json:"issues"`
+}
+
+func (e *ExportValidationError) Error() string {
+	return "pack inválido para exportação: " + strings.Join(e.Issues, "; ")
+}
+
+// ValidateForExport aplica todas as regras de plataforma no pack e nos stickers referenciados.
+func ValidateForExport(p *Pack, stickers []Sticker) error {
+	var issues []string
+
+	if p.IDModerationBanned != nil {
+		issues = append(issues, "pack banido pela moderação")
+	}
+	if p.IsDeleted {
+		issues = append(issues, "pack deletado")
+	}
+	if l := len(strings.TrimSpace(p.Name)); l < 3 || l > 64 {
+		issues = append(issues, "nome deve ter entre 3 e 64 caracteres")
+	}
+	if p.TrayImageURL == "" {
+		issues = append(issues, "tray_image_url obrigatório")
+	}
+	if n := len(stickers); n < ExportMinStickers || n > ExportMaxStickers {
+		issues = append(issues, fmt.Sprintf("pack deve ter entre %d e %d stickers (tem %d)", ExportMinStickers, ExportMaxStickers, n))
+	}
+
+	for _, s := range stickers {
+		switch {
+		case s.IsDeleted:
+			issues = append(issues, fmt.Sprintf("sticker %d deletado", s.ID))
+		case s.IsModerated:
+			issues = append(issues, fmt.Sprintf("sticker %d moderado", s.ID))
+		}
+		if s.Width != ExportStickerSize || s.Height != ExportStickerSize {
+			issues = append(issues, fmt.Sprintf("sticker %d deve ter %dx%dpx", s.ID, ExportStickerSize, ExportStickerSize))
+		}
+		if s.SizeInBytes <= 0 || s.SizeInBytes > ExportMaxStickerBytes {
+			issues = append(issues, fmt.Sprintf("sticker %d excede o tamanho máximo", s.ID))
+		}
+		if n := len(s.Emojis); n < 1 || n > ExportMaxStickerEmojis {
+			issues = append(issues, fmt.Sprintf("sticker %d deve ter de 1 a %d emojis", s.ID, ExportMaxStickerEmojis))
+		}
+		if s.IDMaker != p.IDMaker && !s.IsReusable {
+			issues = append(issues, fmt.Sprintf("sticker %d não é reutilizável", s.ID))
+		}
+	}
+
+	if len(issues) > 0 {
+		return &ExportValidationError{Issues: issues}
+	}
+	return nil
+}
+
+// ValidateDownload: Exportação/Download para o WhatsApp. Regras de plataforma + teto de classificação do usuário.
+// ceiling: ViewerMaxRating de quem está baixando.
+func ValidateDownload(p *Pack, stickers []Sticker, ceiling ContentRating) error {
+	if !p.IsPublished() {
+		return fmt.Errorf("%w: pack não publicado", ErrInvalidTransition)
+	}
+	if err := CheckExport(p, ceiling); err != nil {
+		return err
+	}
+	return ValidateForExport(p, stickers)
+}
+
+/*
+REGRAS DO CICLO DE VIDA:
+1.  Nascimento: Todo pack nasce como Rascunho (PackDraft), invisível para o público.
+2.  Curadoria: Se 'NeedsReview' (Triage ausente, obsoleta ou < 50), o envio vai para PackInReview em vez de publicar direto.
+3.  Publicação: Só publica se passar em 'ValidateForExport'. Erros são devolvidos todos juntos.
+4.  Contadores: A transição para PackPublished incrementa Maker.PacksCreatedCount, Anime.PacksCount e (se for o primeiro pack do Maker no anime) Anime.MakersCount.
+5.  Idempotência: Publicar um pack já publicado é transição inválida; os contadores nunca sobem duas vezes.
+6.  Despublicação: PackPublished -> PackUnpublished desfaz exatamente os incrementos. Republicar conta de novo.
+7.  Visibilidade: 'IsVisible' é derivado do status (Publicado = true). Não é editado diretamente pelos inputs.
+8.  Legado: Packs com Status vazio são tratados pelo antigo 'IsVisible' (EffectiveStatus) até a migração 0001 preencher a coluna.
+9.  Classificação: Publicar recalcula 'ContentRating' pelos stickers. Download/Exportação passa por 'ValidateDownload' (teto do usuário).
+*/
@@ -0,0 +1,202 @@
+package models
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// exportStickers: n stickers do Maker 1 dentro das regras do WhatsApp.
+func exportStickers(n int) []Sticker {
+	out := make([]Sticker, n)
+	for i := range out {
+		out[i] = Sticker{ID: int64(i + 1), IDMaker: 1, Width: ExportStickerSize, Height: ExportStickerSize,
+			SizeInBytes: 1000, Emojis: []string{"😀"}, IsVisible: true}
+	}
+	return out
+}
+
+func exportPack(status PackStatus, triage float32) *Pack {
+	triagedAt := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	return &Pack{ID: 1, IDMaker: 1, Name: "Luffy memes", TrayImageURL: "https://cdn/tray.png", Status: status,
+		Triage: &triage, TriagedAt: &triagedAt}
+}
+
+func TestCanTransition(t *testing.T) {
+	cases := []struct {
+		from PackStatus
+		to   PackStatus
+		want bool
+	}{
+		{PackDraft, PackPublished, true},
+		{PackDraft, PackInReview, true},
+		{PackDraft, PackUnpublished, false},
+		{PackInReview, PackPublished, true},
+		{PackInReview, PackUnpublished, false},
+		{PackPublished, PackPublished, false},
+		{PackPublished, PackUnpublished, true},
+		{PackPublished, PackDraft, false},
+		{PackUnpublished, PackPublished, true},
+		{"", PackPublished, true}, // Legado oculto = rascunho.
+	}
+	for _, tc := range cases {
+		p := &Pack{Status: tc.from}
+		if got := p.CanTransition(tc.to); got != tc.want {
+			t.Errorf("%q -> %q = %v, want %v", tc.from, tc.to, got, tc.want)
+		}
+	}
+}
+
+func TestEffectiveStatus(t *testing.T) {
+	cases := []struct {
+		name string
+		pack Pack
+		want PackStatus
+	}{
+		{"status gravado vence", Pack{Status: PackInReview, IsVisible: true}, PackInReview},
+		{"legado visível", Pack{IsVisible: true}, PackPublished},
+		{"legado oculto", Pack{}, PackDraft},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.pack.EffectiveStatus(); got != tc.want {
+				t.Fatalf("EffectiveStatus = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestPublish(t *testing.T) {
+	now := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	adult := exportStickers(3)
+	adult[0].ContentRating = RatingAdult
+
+	cases := []struct {
+		name        string
+		pack        *Pack
+		stickers    []Sticker
+		inAnime     bool
+		wantStatus  PackStatus
+		wantErr     bool
+		wantPacks   uint64
+		wantMakers  uint64
+		wantCreated uint64
+		wantRating  ContentRating
+	}{
+		{"rascunho bom publica e conta", exportPack(PackDraft, 80), exportStickers(3), false, PackPublished, false, 1, 1, 1, RatingSafe},
+		{"maker já no anime não conta de novo", exportPack(PackDraft, 80), exportStickers(3), true, PackPublished, false, 1, 0, 1, RatingSafe},
+		{"triagem baixa vai para curadoria", exportPack(PackDraft, 10), exportStickers(3), false, PackInReview, false, 0, 0, 0, RatingSafe},
+		{"aprovação da curadoria publica direto", exportPack(PackInReview, 10), exportStickers(3), false, PackPublished, false, 1, 1, 1, RatingSafe},
+		{"sticker adulto escala o pack", exportPack(PackDraft, 80), adult, false, PackPublished, false, 1, 1, 1, RatingAdult},
+		{"inválido não muda nada", exportPack(PackDraft, 80), adult[:2], false, PackDraft, true, 0, 0, 0, ""},
+		{"já publicado é transição inválida", exportPack(PackPublished, 80), exportStickers(3), false, PackPublished, true, 0, 0, 0, ""},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			maker, anime := &Maker{}, &Anime{}
+			err := tc.pack.Publish(tc.stickers, maker, anime, tc.inAnime, now)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Publish err = %v, wantErr %v", err, tc.wantErr)
+			}
+			if tc.pack.Status != tc.wantStatus || tc.pack.ContentRating != tc.wantRating {
+				t.Fatalf("status/rating = %q/%q, want %q/%q", tc.pack.Status, tc.pack.ContentRating, tc.wantStatus, tc.wantRating)
+			}
+			if anime.PacksCount != tc.wantPacks || anime.MakersCount != tc.wantMakers || maker.PacksCreatedCount != tc.wantCreated {
+				t.Fatalf("counters = %d/%d/%d, want %d/%d/%d", anime.PacksCount, anime.MakersCount, maker.PacksCreatedCount,
+					tc.wantPacks, tc.wantMakers, tc.wantCreated)
+			}
+			if !tc.wantErr && tc.pack.IsVisible != (tc.wantStatus == PackPublished) {
+				t.Fatalf("IsVisible = %v for status %q", tc.pack.IsVisible, tc.pack.Status)
+			}
+		})
+	}
+}
+
+func TestUnpublishRepublish(t *testing.T) {
+	now := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	p := exportPack(PackDraft, 80)
+	maker, anime := &Maker{}, &Anime{}
+
+	steps := []struct {
+		name        string
+		run         func() error
+		wantPacks   uint64
+		wantCreated uint64
+	}{
+		{"publica", func() error { return p.Publish(exportStickers(3), maker, anime, false, now) }, 1, 1},
+		{"despublica desfaz", func() error { return p.Unpublish(maker, anime, false, now) }, 0, 0},
+		{"despublicar de novo é inválido", func() error { return p.Unpublish(maker, anime, false, now) }, 0, 0},
+		{"republica conta de novo", func() error { return p.Publish(exportStickers(3), maker, anime, false, now) }, 1, 1},
+	}
+	for i, step := range steps {
+		err := step.run()
+		if wantErr := i == 2; (err != nil) != wantErr || (err != nil && !errors.Is(err, ErrInvalidTransition)) {
+			t.Fatalf("%s: err = %v", step.name, err)
+		}
+		if anime.PacksCount != step.wantPacks || maker.PacksCreatedCount != step.wantCreated {
+			t.Fatalf("%s: counters = %d/%d, want %d/%d", step.name, anime.PacksCount, maker.PacksCreatedCount, step.wantPacks, step.wantCreated)
+		}
+	}
+	if p.NeedsReview() {
+		t.Fatal("republicar não deve invalidar a triagem")
+	}
+}
+
+func TestValidateForExport(t *testing.T) {
+	banned := int64(1)
+	notReusable := exportStickers(3)
+	notReusable[1].IDMaker = 2
+	bigEmojis := exportStickers(3)
+	bigEmojis[2].Emojis = []string{"1", "2", "3", "4"}
+
+	cases := []struct {
+		name       string
+		pack       *Pack
+		stickers   []Sticker
+		wantIssues int
+	}{
+		{"válido", exportPack(PackDraft, 80), exportStickers(3), 0},
+		{"poucos stickers", exportPack(PackDraft, 80), exportStickers(2), 1},
+		{"muitos stickers", exportPack(PackDraft, 80), exportStickers(31), 1},
+		{"sticker de outro maker não reutilizável", exportPack(PackDraft, 80), notReusable, 1},
+		{"emojis demais", exportPack(PackDraft, 80), bigEmojis, 1},
+		{"banido e sem tray", &Pack{IDMaker: 1, Name: "abc", IDModerationBanned: &banned}, exportStickers(3), 2},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateForExport(tc.pack, tc.stickers)
+			var ve *ExportValidationError
+			switch {
+			case tc.wantIssues == 0 && err != nil:
+				t.Fatalf("err = %v, want nil", err)
+			case tc.wantIssues > 0 && (!errors.As(err, &ve) || len(ve.Issues) != tc.wantIssues):
+				t.Fatalf("err = %v, want %d issues", err, tc.wantIssues)
+			}
+		})
+	}
+}
+
+func TestValidateDownload(t *testing.T) {
+	published := exportPack(PackPublished, 80)
+	adult := exportPack(PackPublished, 80)
+	adult.ContentRating = RatingAdult
+
+	cases := []struct {
+		name    string
+		pack    *Pack
+		ceiling ContentRating
+		want    error
+	}{
+		{"publicado e livre", published, RatingSafe, nil},
+		{"rascunho não baixa", exportPack(PackDraft, 80), RatingAdult, ErrInvalidTransition},
+		{"adulto acima do teto", adult, RatingSuggestive, ErrContentRestricted},
+		{"adulto liberado", adult, RatingAdult, nil},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := ValidateDownload(tc.pack, exportStickers(3), tc.ceiling); !errors.Is(err, tc.want) {
+				t.Fatalf("ValidateDownload = %v, want %v", err, tc.want)
+			}
+		})
+	}
+}
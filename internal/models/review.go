@@ -0,0 +1,163 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// ==========================================================
+// 1. ENTIDADE PRINCIPAL (A Avaliação)
+// ==========================================================
+
+// Limites da nota (estrelas).
+const (
+	ReviewMinRating int8 = 1
+	ReviewMaxRating int8 = 5
+)
+
+// BayesianMinVotes: Quantidade de votos "fantasmas" com a média global.
+// Packs com poucos votos ficam puxados para a média até acumularem avaliações reais.
+const BayesianMinVotes = 10
+
+var ErrInvalidRating = errors.New("nota inválida: deve estar entre 1 e 5")
+
+// PackReview: Avaliação de um Maker sobre um Pacote.
+// Regra: Uma avaliação por Maker por Pack (índice único composto).
+type PackReview struct {
+	ID int64 `json:"id" db:"id" gorm:"primaryKey"`
+
+	IDPack  int64 `json:"id_pack" db:"id_pack" gorm:"uniqueIndex:idx_review_pack_maker"`
+	IDMaker int64 `json:"id_maker" db:"id_maker" gorm:"uniqueIndex:idx_review_pack_maker"`
+
+	// Rating: Estrelas (1-5). Alimenta 'Pack.Score'.
+	Rating int8 `json:"rating" db:"rating"`
+	// Text: Comentário opcional.
+	Text *string `json:"text" db:"text"`
+
+	// CONTROLE E MODERAÇÃO
+	// Denúncias usam Moderation com TargetType = TargetReview e IDTarget = ID.
+	IsModerated  bool   `json:"is_moderated" db:"is_moderated"`
+	IDModeration *int64 `json:"id_moderation" db:"id_moderation"`
+
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at" db:"updated_at"`
+	EditedAt  *time.Time `json:"edited_at" db:"edited_at"` // Preenchido quando o autor edita.
+}
+
+// ==========================================================
+// 2. INPUTS E DTOs
+// ==========================================================
+
+type CreatePackReviewInput struct {
+	Rating int8    `json:"rating" binding:"required,min=1,max=5"`
+	Text   *string `json:"text" binding:"omitempty,max=500"`
+}
+
+type UpdatePackReviewInput struct {
+	Rating *int8   `json:"rating" binding:"omitempty,min=1,max=5"`
+	Text   *string `json:"text" binding:"omitempty,max=500"`
+}
+
+type PackReviewResponse struct {
+	ID        int64      `json:"id"`
+	IDPack    int64      `json:"id_pack"`
+	IDMaker   int64      `json:"id_maker"`
+	Rating    int8       `json:"rating"`
+	Text      *string    `json:"text"`
+	IsEdited  bool       `json:"is_edited"`
+	CreatedAt time.Time  `json:"created_at"`
+	EditedAt  *time.Time `json:"edited_at"`
+}
+
+// Mapper
+func (r *PackReview) ToResponse() PackReviewResponse {
+	return PackReviewResponse{
+		ID:        r.ID,
+		IDPack:    r.IDPack,
+		IDMaker:   r.IDMaker,
+		Rating:    r.Rating,
+		Text:      r.Text,
+		IsEdited:  r.EditedAt != nil,
+		CreatedAt: r.CreatedAt,
+		EditedAt:  r.EditedAt,
+	}
+}
+
+// ValidRating verifica se a nota está dentro da escala de estrelas.
+func ValidRating(rating int8) bool {
+	return rating >= ReviewMinRating && rating <= ReviewMaxRating
+}
+
+// Edit aplica a edição do autor e devolve a nota anterior,
+// que o Service repassa para 'Pack.ApplyReview'.
+func (r *PackReview) Edit(in UpdatePackReviewInput, now time.Time) (oldRating int8, err error) {
+	oldRating = r.Rating
+	if in.Rating != nil {
+		if !ValidRating(*in.Rating) {
+			return oldRating, ErrInvalidRating
+		}
+		r.Rating = *in.Rating
+	}
+	if in.Text != nil {
+		r.Text = in.Text
+	}
+	r.UpdatedAt = now
+	r.EditedAt = &now
+	return oldRating, nil
+}
+
+// ==========================================================
+// 3. SCORE DO PACK (Atualização Incremental)
+// ==========================================================
+
+// ApplyReview atualiza 'Score' sem recalcular a média na tabela inteira.
+// oldRating = 0: nova avaliação. newRating = 0: avaliação removida/moderada.
+// Ambos preenchidos: edição.
+func (p *Pack) ApplyReview(oldRating, newRating int8) {
+	if oldRating > 0 && p.ReviewsCount > 0 {
+		p.ReviewsCount--
+		decrementBy(&p.RatingsSum, uint64(oldRating))
+	}
+	if newRating > 0 {
+		p.ReviewsCount++
+		p.RatingsSum += uint64(newRating)
+	}
+
+	if p.ReviewsCount == 0 {
+		p.Score = nil
+		return
+	}
+	avg := float32(p.RatingsSum) / float32(p.ReviewsCount)
+	p.Score = &avg
+}
+
+// decrementBy: Mesmo cuidado de 'decrement' para subtrações maiores que 1 (contadores que divergiram não dão a volta).
+func decrementBy(counter *uint64, n uint64) {
+	if *counter < n {
+		*counter = 0
+		return
+	}
+	*counter -= n
+}
+
+// BayesianScore: Nota ponderada para rankings.
+// Fórmula: (v/(v+m))*R + (m/(v+m))*C, onde C é a média global de todos os packs.
+// Evita que um pack novo com uma única nota 5 passe na frente de um pack com 500 notas 4.8.
+func (p *Pack) BayesianScore(globalMean float32) float32 {
+	v := float32(p.ReviewsCount)
+	m := float32(BayesianMinVotes)
+	if p.Score == nil || v == 0 {
+		return globalMean
+	}
+	return (v/(v+m))*(*p.Score) + (m/(v+m))*globalMean
+}
+
+/*
+REGRAS DE AVALIAÇÃO (REVIEW):
+1.  Unicidade: Cada Maker pode avaliar um Pack apenas uma vez (IDPack + IDMaker). Nova tentativa = edição.
+2.  Escala: Nota obrigatória de 1 a 5 estrelas. Texto é opcional.
+3.  Edição: O autor pode editar nota e texto. 'EditedAt' sinaliza a edição na UI.
+4.  Denúncia: Reviews são denunciáveis via Moderation (TargetReview). Review moderada sai da média (ApplyReview(nota, 0)).
+5.  Score: 'Pack.Score' é atualizado incrementalmente com 'ReviewsCount' e 'RatingsSum', sem AVG() na tabela.
+6.  Ranking: Listagens ordenadas por nota devem usar 'BayesianScore' para não premiar packs com poucos votos.
+*/
@@ -0,0 +1,92 @@
+package models
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestApplyReview(t *testing.T) {
+	score := func(v float32) *float32 { return &v }
+
+	cases := []struct {
+		name      string
+		pack      Pack
+		old, new  int8
+		wantCount uint64
+		wantSum   uint64
+		wantScore *float32
+	}{
+		{"primeira avaliação", Pack{}, 0, 5, 1, 5, score(5)},
+		{"nova avaliação soma", Pack{ReviewsCount: 1, RatingsSum: 5}, 0, 3, 2, 8, score(4)},
+		{"edição troca a nota", Pack{ReviewsCount: 2, RatingsSum: 8}, 3, 1, 2, 6, score(3)},
+		{"remoção da última zera o score", Pack{ReviewsCount: 1, RatingsSum: 4}, 4, 0, 0, 0, nil},
+		{"soma divergente não dá a volta", Pack{ReviewsCount: 2, RatingsSum: 2}, 5, 0, 1, 0, score(0)},
+		{"remoção sem contador é ignorada", Pack{}, 4, 0, 0, 0, nil},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := tc.pack
+			p.ApplyReview(tc.old, tc.new)
+			if p.ReviewsCount != tc.wantCount || p.RatingsSum != tc.wantSum {
+				t.Fatalf("count/sum = %d/%d, want %d/%d", p.ReviewsCount, p.RatingsSum, tc.wantCount, tc.wantSum)
+			}
+			switch {
+			case tc.wantScore == nil && p.Score != nil:
+				t.Fatalf("score = %v, want nil", *p.Score)
+			case tc.wantScore != nil && (p.Score == nil || *p.Score != *tc.wantScore):
+				t.Fatalf("score = %v, want %v", p.Score, *tc.wantScore)
+			}
+		})
+	}
+}
+
+func TestBayesianScore(t *testing.T) {
+	five, high := float32(5), float32(4.8)
+
+	cases := []struct {
+		name string
+		pack Pack
+		want float32
+	}{
+		{"sem votos usa a média global", Pack{}, 3},
+		{"um voto 5 fica perto da média", Pack{ReviewsCount: 1, Score: &five}, (1.0/11)*5 + (10.0/11)*3},
+		{"muitos votos ficam perto da própria nota", Pack{ReviewsCount: 490, Score: &high}, (490.0/500)*4.8 + (10.0/500)*3},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.pack.BayesianScore(3); math.Abs(float64(got-tc.want)) > 1e-4 {
+				t.Fatalf("BayesianScore = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestEditReview(t *testing.T) {
+	rating := func(v int8) *int8 { return &v }
+	now := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name       string
+		in         UpdatePackReviewInput
+		wantOld    int8
+		wantRating int8
+		wantErr    error
+	}{
+		{"troca a nota", UpdatePackReviewInput{Rating: rating(2)}, 4, 2, nil},
+		{"só texto mantém a nota", UpdatePackReviewInput{}, 4, 4, nil},
+		{"nota fora da escala", UpdatePackReviewInput{Rating: rating(6)}, 4, 4, ErrInvalidRating},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := PackReview{Rating: 4}
+			old, err := r.Edit(tc.in, now)
+			if err != tc.wantErr || old != tc.wantOld || r.Rating != tc.wantRating {
+				t.Fatalf("Edit = (%d, %v) rating %d, want (%d, %v) rating %d", old, err, r.Rating, tc.wantOld, tc.wantErr, tc.wantRating)
+			}
+			if err == nil && (r.EditedAt == nil || !r.EditedAt.Equal(now)) {
+				t.Fatalf("EditedAt = %v, want %v", r.EditedAt, now)
+			}
+		})
+	}
+}
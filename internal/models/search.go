@@ -0,0 +1,55 @@
+package models
+
+// ==========================================================
+// 1. BUSCA (Query + Resposta)
+// ==========================================================
+
+// SearchQuery: Query string do endpoint público de busca.
+// O índice fica em internal/search; aqui ficam apenas o contrato HTTP e a resposta.
+type SearchQuery struct {
+	Q      string `form:"q" binding:"required,min=1,max=64"`
+	Type   string `form:"type" binding:"omitempty,oneof=sticker pack"`
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=50"`
+	Offset int    `form:"offset" binding:"omitempty,min=0"`
+}
+
+// SearchResponse: Resultados já filtrados (classificação e spoiler) e mapeados na ordem do ranking.
+type SearchResponse struct {
+	Stickers []StickerResponse `json:"stickers"`
+	Packs    []PackResponse    `json:"packs"`
+	Total    int               `json:"total"`
+}
+
+// ==========================================================
+// 2. AUTOCOMPLETE
+// ==========================================================
+
+// Grupos fora de KeywordCategory.
+const (
+	SuggestGroupAnime = "anime"
+	SuggestGroupMaker = "maker"
+)
+
+// AutocompleteQuery: Sugestões enquanto o usuário digita.
+type AutocompleteQuery struct {
+	Q     string `form:"q" binding:"required,min=1,max=32"`
+	Limit int    `form:"limit" binding:"omitempty,min=1,max=10"` // Por grupo.
+}
+
+// AutocompleteItem: Sugestão. Slug para keywords, Nickname para makers, ID para animes.
+type AutocompleteItem struct {
+	ID       int64   `json:"id"`
+	Slug     string  `json:"slug"`
+	Name     string  `json:"name"` // No idioma do cliente.
+	ImageURL *string `json:"image_url"`
+}
+
+// AutocompleteGroup: Group é uma KeywordCategory ("character", "emotion"...), "anime" ou "maker".
+type AutocompleteGroup struct {
+	Group string             `json:"group"`
+	Items []AutocompleteItem `json:"items"`
+}
+
+type AutocompleteResponse struct {
+	Groups []AutocompleteGroup `json:"groups"`
+}
@@ -0,0 +1,217 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// ==========================================================
+// 1. MARCAÇÃO DE SPOILER (Sticker e Pack)
+// ==========================================================
+
+var ErrSpoilerAnimeRequired = errors.New("spoiler precisa de um anime: informe 'id_anime'")
+
+// SpoilerMode: O que fazer com spoilers que o usuário ainda não liberou.
+type SpoilerMode string
+
+const (
+	SpoilerModeBlur SpoilerMode = "blur" // Default: aparece borrado, com aviso.
+	SpoilerModeHide SpoilerMode = "hide" // Some dos feeds e da busca.
+)
+
+// SpoilerAction: Decisão final para um item diante de um usuário.
+type SpoilerAction int8
+
+const (
+	SpoilerShow SpoilerAction = 0
+	SpoilerBlur SpoilerAction = 1
+	SpoilerHide SpoilerAction = 2
+)
+
+// SpoilerInput: Marcação enviada pelo Maker. Episode vazio = spoiler da obra inteira (ex: final).
+type SpoilerInput struct {
+	IDAnime *int64 `json:"id_anime" binding:"omitempty,gt=0"` // Vazio = anime do próprio item.
+	Episode *int16 `json:"episode" binding:"omitempty,gt=0"`
+}
+
+// SpoilerResponse: Aviso exibido sobre o item.
+type SpoilerResponse struct {
+	IDAnime   int64  `json:"id_anime"`
+	Episode   *int16 `json:"episode"`
+	IsBlurred bool   `json:"is_blurred"`
+}
+
+// ==========================================================
+// 2. PREFERÊNCIA DO USUÁRIO (Por Anime)
+// ==========================================================
+
+// MakerAnimeSpoiler: "Estou seguro até o episódio N" de um anime específico.
+// Fica ao lado de MakerSettings (que guarda o SpoilerMode global).
+type MakerAnimeSpoiler struct {
+	IDMaker int64 `json:"id_maker" db:"id_maker" gorm:"primaryKey"`
+	IDAnime int64 `json:"id_anime" db:"id_anime" gorm:"primaryKey"`
+
+	// SafeUpToEpisode: Spoilers até este episódio (inclusive) são liberados.
+	SafeUpToEpisode int16 `json:"safe_up_to_episode" db:"safe_up_to_episode"`
+	// HasFinished: Terminou a obra. Libera tudo, inclusive spoilers sem episódio.
+	HasFinished bool `json:"has_finished" db:"has_finished"`
+
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+type UpdateAnimeSpoilerInput struct {
+	SafeUpToEpisode *int16 `json:"safe_up_to_episode" binding:"omitempty,gte=0"`
+	HasFinished     *bool  `json:"has_finished"`
+}
+
+// SpoilerPrefs: Preferências já carregadas do usuário (uma consulta por request).
+type SpoilerPrefs struct {
+	Mode     SpoilerMode
+	Progress map[int64]MakerAnimeSpoiler // Chave: IDAnime
+}
+
+// NewSpoilerPrefs: settings nil = visitante anônimo (borra tudo, não liberou nada).
+func NewSpoilerPrefs(settings *MakerSettings, progress []MakerAnimeSpoiler) SpoilerPrefs {
+	prefs := SpoilerPrefs{Mode: SpoilerModeBlur, Progress: make(map[int64]MakerAnimeSpoiler, len(progress))}
+	if settings != nil && settings.SpoilerMode == SpoilerModeHide {
+		prefs.Mode = SpoilerModeHide
+	}
+	for _, p := range progress {
+		prefs.Progress[p.IDAnime] = p
+	}
+	return prefs
+}
+
+// Decide aplica a regra: liberado pelo progresso = mostra; senão, borra ou esconde conforme o modo.
+func (p SpoilerPrefs) Decide(isSpoiler bool, idAnime *int64, episode *int16) SpoilerAction {
+	if !isSpoiler || idAnime == nil {
+		return SpoilerShow
+	}
+	if progress, ok := p.Progress[*idAnime]; ok {
+		if progress.HasFinished {
+			return SpoilerShow
+		}
+		if episode != nil && *episode <= progress.SafeUpToEpisode {
+			return SpoilerShow
+		}
+	}
+	if p.Mode == SpoilerModeHide {
+		return SpoilerHide
+	}
+	return SpoilerBlur
+}
+
+// ==========================================================
+// 3. APLICAÇÃO NOS ITENS
+// ==========================================================
+
+// setSpoiler resolve a marcação. O anime padrão é o do próprio item.
+// Sem anime resolvido a marcação é recusada: um spoiler sem anime nunca seria liberado pelo progresso.
+func setSpoiler(in *SpoilerInput, defaultAnime *int64) (bool, *int64, *int16, error) {
+	if in == nil {
+		return false, nil, nil, nil
+	}
+	idAnime := in.IDAnime
+	if idAnime == nil {
+		idAnime = defaultAnime
+	}
+	if idAnime == nil {
+		return false, nil, nil, ErrSpoilerAnimeRequired
+	}
+	return true, idAnime, in.Episode, nil
+}
+
+// SetSpoiler marca (ou desmarca, com nil) o sticker como spoiler. Em erro, o sticker não muda.
+func (s *Sticker) SetSpoiler(in *SpoilerInput) error {
+	isSpoiler, idAnime, episode, err := setSpoiler(in, s.IDAnime)
+	if err != nil {
+		return err
+	}
+	s.IsSpoiler, s.SpoilerAnimeID, s.SpoilerEpisode = isSpoiler, idAnime, episode
+	return nil
+}
+
+// SetSpoiler marca (ou desmarca, com nil) o pack como spoiler. Em erro, o pack não muda.
+func (p *Pack) SetSpoiler(in *SpoilerInput) error {
+	var idAnime *int64
+	if p.IDAnime > 0 {
+		id := p.IDAnime
+		idAnime = &id
+	}
+	isSpoiler, spoilerAnime, episode, err := setSpoiler(in, idAnime)
+	if err != nil {
+		return err
+	}
+	p.IsSpoiler, p.SpoilerAnimeID, p.SpoilerEpisode = isSpoiler, spoilerAnime, episode
+	return nil
+}
+
+func (s *Sticker) SpoilerAction(prefs SpoilerPrefs) SpoilerAction {
+	return prefs.Decide(s.IsSpoiler, s.SpoilerAnimeID, s.SpoilerEpisode)
+}
+
+func (p *Pack) SpoilerAction(prefs SpoilerPrefs) SpoilerAction {
+	return prefs.Decide(p.IsSpoiler, p.SpoilerAnimeID, p.SpoilerEpisode)
+}
+
+func spoilerResponse(isSpoiler bool, idAnime *int64, episode *int16, action SpoilerAction) *SpoilerResponse {
+	if !isSpoiler || idAnime == nil {
+		return nil
+	}
+	return &SpoilerResponse{IDAnime: *idAnime, Episode: episode, IsBlurred: action == SpoilerBlur}
+}
+
+// StickersForViewer: Mapeia a lista para o usuário, removendo os escondidos e marcando os borrados.
+// Usado por feeds e busca. ceiling: ViewerMaxRating do usuário (acima do teto some, como o spoiler escondido).
+func StickersForViewer(stickers []Sticker, prefs SpoilerPrefs, ceiling ContentRating) []StickerResponse {
+	stickers = FilterStickersByRating(stickers, ceiling)
+	out := make([]StickerResponse, 0, len(stickers))
+	for i := range stickers {
+		s := &stickers[i]
+		action := s.SpoilerAction(prefs)
+		if action == SpoilerHide {
+			continue
+		}
+		resp := s.ToResponse()
+		resp.Spoiler = spoilerResponse(s.IsSpoiler, s.SpoilerAnimeID, s.SpoilerEpisode, action)
+		out = append(out, resp)
+	}
+	return out
+}
+
+// PacksForViewer: Mesmo filtro para packs já mapeados (BuildPackResponses).
+// Cada resposta é casada com o pack pelo ID (a ordem das listas não importa); a saída segue a ordem de 'packs'.
+// Pack sem resposta é descartado. Packs acima do teto (ceiling) são removidos.
+func PacksForViewer(packs []Pack, responses []PackResponse, prefs SpoilerPrefs, ceiling ContentRating) []PackResponse {
+	byID := make(map[int64]PackResponse, len(responses))
+	for _, r := range responses {
+		byID[r.ID] = r
+	}
+	out := make([]PackResponse, 0, len(responses))
+	for i := range packs {
+		p := &packs[i]
+		resp, ok := byID[p.ID]
+		if !ok || !ceiling.Allows(p.ContentRating) {
+			continue
+		}
+		action := p.SpoilerAction(prefs)
+		if action == SpoilerHide {
+			continue
+		}
+		resp.Spoiler = spoilerResponse(p.IsSpoiler, p.SpoilerAnimeID, p.SpoilerEpisode, action)
+		out = append(out, resp)
+	}
+	return out
+}
+
+/*
+REGRAS DE SPOILER:
+1.  Marcação: Stickers e Packs podem ser marcados como spoiler de um anime (padrão: o anime do próprio item), opcionalmente até o episódio.
+2.  Sem Episódio: Spoiler sem episódio é tratado como "da obra inteira" e só é liberado para quem marcou 'HasFinished'.
+3.  Preferência: O usuário define, por anime, até qual episódio está seguro (MakerAnimeSpoiler).
+4.  Modo Global: MakerSettings.SpoilerMode decide entre borrar (padrão) ou esconder o que não foi liberado.
+5.  Anônimos: Visitantes sem login veem todo spoiler borrado.
+6.  Feeds e Busca: Sempre passam por StickersForViewer/PacksForViewer antes de responder (que também aplicam o teto de classificação).
+7.  Denúncia: Spoiler sem marcação continua denunciável (ReasonSpoiler). O moderador pode marcar em vez de banir (ActionEditForce).
+8.  Anime Obrigatório: Item sem anime (original) só pode ser marcado informando 'id_anime'. Sem anime resolvido a marcação é recusada.
+*/
@@ -0,0 +1,153 @@
+package models
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSpoilerDecide(t *testing.T) {
+	anime, other := int64(10), int64(11)
+	ep := func(n int16) *int16 { return &n }
+	progress := []MakerAnimeSpoiler{{IDAnime: anime, SafeUpToEpisode: 12}, {IDAnime: other, HasFinished: true}}
+	blur := NewSpoilerPrefs(&MakerSettings{SpoilerMode: SpoilerModeBlur}, progress)
+	hide := NewSpoilerPrefs(&MakerSettings{SpoilerMode: SpoilerModeHide}, progress)
+	cases := []struct {
+		name      string
+		prefs     SpoilerPrefs
+		isSpoiler bool
+		idAnime   *int64
+		episode   *int16
+		want      SpoilerAction
+	}{
+		{"não é spoiler", hide, false, &anime, ep(99), SpoilerShow},
+		{"episódio liberado", hide, true, &anime, ep(12), SpoilerShow},
+		{"episódio à frente borra", blur, true, &anime, ep(13), SpoilerBlur},
+		{"episódio à frente esconde", hide, true, &anime, ep(13), SpoilerHide},
+		{"obra inteira sem terminar", blur, true, &anime, nil, SpoilerBlur},
+		{"obra inteira terminada", hide, true, &other, nil, SpoilerShow},
+		{"anônimo borra tudo", NewSpoilerPrefs(nil, nil), true, &anime, ep(1), SpoilerBlur},
+	}
+	for _, tc := range cases {
+		if got := tc.prefs.Decide(tc.isSpoiler, tc.idAnime, tc.episode); got != tc.want {
+			t.Errorf("%s: %d, want %d", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestSetSpoiler(t *testing.T) {
+	anime, other := int64(10), int64(11)
+	cases := []struct {
+		name      string
+		sticker   Sticker
+		in        *SpoilerInput
+		wantErr   error
+		wantAnime *int64
+	}{
+		{"anime do próprio sticker", Sticker{IDAnime: &anime}, &SpoilerInput{}, nil, &anime},
+		{"anime informado", Sticker{IDAnime: &anime}, &SpoilerInput{IDAnime: &other}, nil, &other},
+		{"original sem anime", Sticker{}, &SpoilerInput{}, ErrSpoilerAnimeRequired, nil},
+		{"desmarcar", Sticker{IDAnime: &anime, IsSpoiler: true, SpoilerAnimeID: &anime}, nil, nil, nil},
+	}
+	for _, tc := range cases {
+		s := tc.sticker
+		err := s.SetSpoiler(tc.in)
+		if !errors.Is(err, tc.wantErr) {
+			t.Errorf("%s: err = %v, want %v", tc.name, err, tc.wantErr)
+			continue
+		}
+		if tc.wantErr != nil {
+			if s.IsSpoiler != tc.sticker.IsSpoiler {
+				t.Errorf("%s: sticker alterado em erro", tc.name)
+			}
+			continue
+		}
+		if (s.SpoilerAnimeID == nil) != (tc.wantAnime == nil) || (tc.wantAnime != nil && *s.SpoilerAnimeID != *tc.wantAnime) {
+			t.Errorf("%s: SpoilerAnimeID = %v, want %v", tc.name, s.SpoilerAnimeID, tc.wantAnime)
+		}
+		if s.IsSpoiler != (tc.in != nil) {
+			t.Errorf("%s: IsSpoiler = %v", tc.name, s.IsSpoiler)
+		}
+	}
+}
+
+func TestPackSetSpoilerOriginal(t *testing.T) {
+	p := Pack{}
+	if err := p.SetSpoiler(&SpoilerInput{}); !errors.Is(err, ErrSpoilerAnimeRequired) {
+		t.Errorf("err = %v, want ErrSpoilerAnimeRequired", err)
+	}
+	p = Pack{IDAnime: 10}
+	if err := p.SetSpoiler(&SpoilerInput{}); err != nil || p.SpoilerAnimeID == nil || *p.SpoilerAnimeID != 10 {
+		t.Errorf("err = %v, SpoilerAnimeID = %v", err, p.SpoilerAnimeID)
+	}
+}
+
+func TestStickersForViewer(t *testing.T) {
+	anime := int64(10)
+	stickers := []Sticker{
+		{ID: 1},
+		{ID: 2, IsSpoiler: true, SpoilerAnimeID: &anime},
+		{ID: 3, ContentRating: RatingAdult},
+	}
+	cases := []struct {
+		name        string
+		prefs       SpoilerPrefs
+		ceiling     ContentRating
+		wantIDs     []int64
+		wantBlurred int64
+	}{
+		{"anônimo", NewSpoilerPrefs(nil, nil), RatingSafe, []int64{1, 2}, 2},
+		{"esconde", NewSpoilerPrefs(&MakerSettings{SpoilerMode: SpoilerModeHide}, nil), RatingAdult, []int64{1, 3}, 0},
+	}
+	for _, tc := range cases {
+		out := StickersForViewer(stickers, tc.prefs, tc.ceiling)
+		if len(out) != len(tc.wantIDs) {
+			t.Errorf("%s: %d stickers, want %v", tc.name, len(out), tc.wantIDs)
+			continue
+		}
+		for i, id := range tc.wantIDs {
+			if out[i].ID != id {
+				t.Errorf("%s: out[%d] = %d, want %d", tc.name, i, out[i].ID, id)
+			}
+			blurred := out[i].Spoiler != nil && out[i].Spoiler.IsBlurred
+			if blurred != (id == tc.wantBlurred) {
+				t.Errorf("%s: sticker %d borrado = %v", tc.name, id, blurred)
+			}
+		}
+	}
+}
+
+func TestPacksForViewerMatchesByID(t *testing.T) {
+	anime := int64(10)
+	packs := []Pack{
+		{ID: 1},
+		{ID: 2, IsSpoiler: true, SpoilerAnimeID: &anime},
+		{ID: 3, ContentRating: RatingAdult},
+		{ID: 4}, // Sem resposta mapeada.
+	}
+	cases := []struct {
+		name      string
+		responses []PackResponse
+		prefs     SpoilerPrefs
+		ceiling   ContentRating
+		wantIDs   []int64
+	}{
+		{"mesma ordem", []PackResponse{{ID: 1, Name: "1"}, {ID: 2, Name: "2"}, {ID: 3, Name: "3"}}, NewSpoilerPrefs(nil, nil), RatingAdult, []int64{1, 2, 3}},
+		{"ordem trocada", []PackResponse{{ID: 3, Name: "3"}, {ID: 1, Name: "1"}, {ID: 2, Name: "2"}}, NewSpoilerPrefs(nil, nil), RatingSafe, []int64{1, 2}},
+		{"spoiler escondido", []PackResponse{{ID: 2, Name: "2"}, {ID: 1, Name: "1"}}, NewSpoilerPrefs(&MakerSettings{SpoilerMode: SpoilerModeHide}, nil), RatingSafe, []int64{1}},
+	}
+	for _, tc := range cases {
+		out := PacksForViewer(packs, tc.responses, tc.prefs, tc.ceiling)
+		if len(out) != len(tc.wantIDs) {
+			t.Errorf("%s: %d packs, want %v", tc.name, len(out), tc.wantIDs)
+			continue
+		}
+		for i, id := range tc.wantIDs {
+			if out[i].ID != id || out[i].Name != string(rune('0'+id)) {
+				t.Errorf("%s: out[%d] = %+v, want pack %d", tc.name, i, out[i], id)
+			}
+			if id == 2 && (out[i].Spoiler == nil || !out[i].Spoiler.IsBlurred) {
+				t.Errorf("%s: spoiler = %+v, want borrado", tc.name, out[i].Spoiler)
+			}
+		}
+	}
+}
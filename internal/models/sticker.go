@@ -70,6 +70,15 @@ type Sticker struct {
 	// DeletedAt: Data da exclusão.
 	DeletedAt *time.Time `json:"deleted_at" db:"deleted_at"`
 
+	// SPOILER (ver spoiler.go)
+	// IsSpoiler: Revela parte da história. SpoilerAnimeID/SpoilerEpisode definem o escopo.
+	IsSpoiler      bool   `json:"is_spoiler" db:"is_spoiler"`
+	SpoilerAnimeID *int64 `json:"spoiler_anime_id" db:"spoiler_anime_id"`
+	SpoilerEpisode *int16 `json:"spoiler_episode" db:"spoiler_episode"` // Null = obra inteira.
+
+	// CLASSIFICAÇÃO (ver content_rating.go)
+	ContentRating ContentRating `json:"content_rating" db:"content_rating" gorm:"index"`
+
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
 }
@@ -100,20 +109,27 @@ type PackSticker struct {
 // ==========================================================
 
 type StickerResponse struct {
-	ID             int64    `json:"id"`
-	IDAnime        *int64   `json:"id_anime"`
-	IDMaker        int64    `json:"id_maker"` // Dono atual
-	ImageURL       string   `json:"image_url"`
-	ImageThumbURL  string   `json:"image_thumb_url"`
-	Width          int      `json:"width"`
-	Height         int      `json:"height"`
-	Emojis         []string `json:"emojis"`
-	Keywords       []string `json:"keywords"`
-	DownloadsCount uint64   `json:"downloads_count"`
-	LikesCount     uint64   `json:"likes_count"`
-	FavoritesCount uint64   `json:"favorites_count"`
-	PacksCount     uint64   `json:"packs_count"`
-	IsReusable     bool     `json:"is_reusable"`
+	ID             int64         `json:"id"`
+	IDAnime        *int64        `json:"id_anime"`
+	IDMaker        int64         `json:"id_maker"` // Dono atual
+	ImageURL       string        `json:"image_url"`
+	ImageThumbURL  string        `json:"image_thumb_url"`
+	Width          int           `json:"width"`
+	Height         int           `json:"height"`
+	Emojis         []string      `json:"emojis"`
+	Keywords       []string      `json:"keywords"`
+	DownloadsCount uint64        `json:"downloads_count"`
+	LikesCount     uint64        `json:"likes_count"`
+	FavoritesCount uint64        `json:"favorites_count"`
+	PacksCount     uint64        `json:"packs_count"`
+	IsReusable     bool          `json:"is_reusable"`
+	ContentRating  ContentRating `json:"content_rating"`
+
+	// Keywords traduzidas (WithStickerTags).
+	Tags []KeywordResponse `json:"tags"`
+
+	// Preenchido apenas quando o sticker é spoiler (StickersForViewer).
+	Spoiler *SpoilerResponse `json:"spoiler"`
 }
 
 type CreateStickerInput struct {
@@ -131,15 +147,22 @@ type CreateStickerInput struct {
 	Height int `json:"height" binding:"required,gt=0"`
 
 	// Regra de Negócio: WhatsApp exige pelo menos 1 emoji associado.
+	// Salvos como enviados (com tom de pele). A busca normaliza só as chaves do índice (emoji.go).
 	Emojis []string `json:"emojis" binding:"required,min=1"`
 
 	// Maker envia termos livres ["Naruto", "Chorando"].
-	// O Service deve processar isso na tabela de Keywords e salvar os slugs normalizados ["naruto_uzumaki", "crying"].
+	// O Service resolve via KeywordResolver (keyword_resolver.go) e salva os slugs normalizados ["naruto_uzumaki", "crying"].
 	Keywords []string `json:"keywords"`
 
 	// Define se nasce público para reuso (Default: true).
 	IsReusable *bool `json:"is_reusable"`
 
+	// Marcação opcional de spoiler.
+	Spoiler *SpoilerInput `json:"spoiler"`
+
+	// Classificação indicativa (Default: safe).
+	ContentRating ContentRating `json:"content_rating" binding:"omitempty,oneof=safe suggestive adult"`
+
 	// Obrigatório para validar se cabe nos limites (ex: < 500KB).
 	SizeInBytes int64 `json:"size_in_bytes" binding:"required,gt=0"`
 }
@@ -156,12 +179,19 @@ type UpdateStickerInput struct {
 	IsVisible  *bool `json:"is_visible"`
 	IsReusable *bool `json:"is_reusable"`
 
+	// Classificação indicativa. Recalcula os packs que contêm o sticker.
+	ContentRating *ContentRating `json:"content_rating" binding:"omitempty,oneof=safe suggestive adult"`
+
 	// Ações exclusivas de moderação.
 	IsModerated  *bool  `json:"is_moderated"`
 	IDModeration *int64 `json:"id_moderation" binding:"omitempty,gt=0"`
 
 	// Link para versão corrigida.
 	ReplacesStickerID *int64 `json:"replaces_sticker_id" binding:"omitempty,gt=0"`
+
+	// Marcação de spoiler (RemoveSpoiler=true desmarca).
+	Spoiler       *SpoilerInput `json:"spoiler"`
+	RemoveSpoiler bool          `json:"remove_spoiler"`
 }
 
 type ReorderStickersInput struct {
@@ -195,6 +225,7 @@ func (s *Sticker) ToResponse() StickerResponse {
 		FavoritesCount: s.FavoritesCount,
 		PacksCount:     s.PacksCount,
 		IsReusable:     s.IsReusable,
+		ContentRating:  s.ContentRating.Normalize(),
 	}
 }
 
@@ -215,4 +246,5 @@ REGRAS DE USO E REQUISITOS TÉCNICOS:
 REGRAS DE ORGANIZAÇÃO:
 10. Ordenação em Packs: A relação Sticker-Pack (PackSticker) possui um campo 'Position' para permitir ordenação manual dentro do pacote.
 11. Versionamento: O campo 'ReplacesStickerID' permite lançar correções de imagem sem perder as métricas do sticker original.
-*/
\ No newline at end of file
+12. Classificação: Todo sticker tem 'ContentRating' (safe, suggestive, adult). Listagens filtram pelo teto do usuário (content_rating.go).
+*/
@@ -0,0 +1,271 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// ==========================================================
+// 1. LIXEIRA (Soft Delete -> Restore -> Purge)
+// ==========================================================
+
+// TrashRetention: Tempo que um item fica na lixeira antes do Purge definitivo.
+const TrashRetention = 30 * 24 * time.Hour
+
+var (
+	ErrNotInTrash       = errors.New("item não está na lixeira")
+	ErrAlreadyInTrash   = errors.New("item já está na lixeira")
+	ErrRetentionExpired = errors.New("prazo de restauração expirado")
+	ErrPackBelowMinimum = errors.New("pack publicado ficaria com menos stickers que o mínimo exigido")
+)
+
+// TrashCutoff: Itens deletados antes deste instante já podem ser purgados.
+func TrashCutoff(now time.Time) time.Time {
+	return now.Add(-TrashRetention)
+}
+
+// canRestore valida o estado de lixeira comum a Sticker e Pack.
+func canRestore(isDeleted bool, deletedAt *time.Time, now time.Time) error {
+	if !isDeleted || deletedAt == nil {
+		return ErrNotInTrash
+	}
+	if deletedAt.Before(TrashCutoff(now)) {
+		return ErrRetentionExpired
+	}
+	return nil
+}
+
+// isPurgeable: Na lixeira há mais tempo que a retenção.
+func isPurgeable(isDeleted bool, deletedAt *time.Time, now time.Time) bool {
+	return isDeleted && deletedAt != nil && deletedAt.Before(TrashCutoff(now))
+}
+
+// ==========================================================
+// 2. STICKER
+// ==========================================================
+
+// SoftDelete manda o sticker para a lixeira.
+// Os vínculos (PackSticker, favoritos, likes, keywords) ficam intactos para permitir o Restore.
+// packs: pacotes que referenciam o sticker (perdem 1 em StickersCount enquanto ele estiver na lixeira).
+// packStickers: stickers atuais de cada pack (por ID), para recalcular o ContentRating sem este sticker.
+// Recusa (ErrPackBelowMinimum) se algum pack publicado ficar abaixo de ExportMinStickers.
+func (s *Sticker) SoftDelete(maker *Maker, anime *Anime, packs []*Pack, packStickers map[int64][]Sticker, now time.Time) error {
+	if s.IsDeleted {
+		return ErrAlreadyInTrash
+	}
+	for _, p := range packs {
+		if p.IsPublished() && p.StickersCount <= ExportMinStickers {
+			return ErrPackBelowMinimum
+		}
+	}
+	s.IsDeleted = true
+	s.DeletedAt = &now
+	s.UpdatedAt = now
+
+	decrement(&maker.StickersCreatedCount)
+	if anime != nil {
+		decrement(&anime.StickersCount)
+	}
+	for _, p := range packs {
+		decrement(&p.StickersCount)
+		p.StickersSize -= float64(s.SizeInBytes)
+		if p.StickersSize < 0 {
+			p.StickersSize = 0
+		}
+		p.RecomputeRating(liveStickers(packStickers[p.ID], s.ID, nil))
+		p.TouchContent(now)
+	}
+	return nil
+}
+
+// Restore tira o sticker da lixeira e devolve exatamente o que SoftDelete removeu.
+// packStickers: mesmo contrato de SoftDelete (o sticker restaurado volta a contar na classificação).
+func (s *Sticker) Restore(maker *Maker, anime *Anime, packs []*Pack, packStickers map[int64][]Sticker, now time.Time) error {
+	if err := canRestore(s.IsDeleted, s.DeletedAt, now); err != nil {
+		return err
+	}
+	s.IsDeleted = false
+	s.DeletedAt = nil
+	s.UpdatedAt = now
+
+	maker.StickersCreatedCount++
+	if anime != nil {
+		anime.StickersCount++
+	}
+	for _, p := range packs {
+		p.StickersCount++
+		p.StickersSize += float64(s.SizeInBytes)
+		p.RecomputeRating(liveStickers(packStickers[p.ID], s.ID, s))
+		p.TouchContent(now)
+	}
+	return nil
+}
+
+// liveStickers: Stickers fora da lixeira, trocando o sticker 'id' por 'keep' (nil = remove).
+func liveStickers(stickers []Sticker, id int64, keep *Sticker) []Sticker {
+	out := make([]Sticker, 0, len(stickers)+1)
+	for _, st := range stickers {
+		if st.ID != id && !st.IsDeleted {
+			out = append(out, st)
+		}
+	}
+	if keep != nil {
+		out = append(out, *keep)
+	}
+	return out
+}
+
+// IsPurgeable: Pronto para exclusão definitiva.
+func (s *Sticker) IsPurgeable(now time.Time) bool {
+	return isPurgeable(s.IsDeleted, s.DeletedAt, now)
+}
+
+// PrepareStickerPurge ajusta os contadores que dependem dos vínculos apagados no Purge.
+// O Job em seguida remove: PackSticker, MakerStickerFavorite, MakerStickerLike e StickerKeyword.
+// keywords: keywords vinculadas via StickerKeyword.
+func PrepareStickerPurge(s *Sticker, owner *Maker, keywords []*Keyword) {
+	owner.LikesReceivedCount = subtractFloor(owner.LikesReceivedCount, s.LikesCount)
+	for _, k := range keywords {
+		decrement(&k.UsageCount)
+	}
+}
+
+// ==========================================================
+// 3. PACK
+// ==========================================================
+
+// SoftDelete manda o pack para a lixeira.
+// Pack publicado é despublicado antes (desfaz Maker/Anime counters via Unpublish).
+// stickers: stickers do pack (perdem 1 em PacksCount enquanto o pack estiver na lixeira).
+// source: pack de origem quando este é um remix (perde 1 em ForksCount). Nil para pack autoral.
+func (p *Pack) SoftDelete(maker *Maker, anime *Anime, makerStillInAnime bool, source *Pack, stickers []*Sticker, now time.Time) error {
+	if p.IsDeleted {
+		return ErrAlreadyInTrash
+	}
+	if p.IsPublished() {
+		if err := p.Unpublish(maker, anime, makerStillInAnime, now); err != nil {
+			return err
+		}
+	}
+
+	p.IsDeleted = true
+	p.DeletedAt = &now
+	p.UpdatedAt = now
+	for _, s := range stickers {
+		decrement(&s.PacksCount)
+	}
+	if source != nil {
+		decrement(&source.ForksCount)
+	}
+	return nil
+}
+
+// Restore tira o pack da lixeira. Ele volta despublicado; o dono decide se publica de novo.
+// source: mesmo contrato de SoftDelete (o remix volta a contar em ForksCount).
+func (p *Pack) Restore(source *Pack, stickers []*Sticker, now time.Time) error {
+	if err := canRestore(p.IsDeleted, p.DeletedAt, now); err != nil {
+		return err
+	}
+	p.IsDeleted = false
+	p.DeletedAt = nil
+	p.UpdatedAt = now
+	for _, s := range stickers {
+		s.PacksCount++
+	}
+	if source != nil {
+		source.ForksCount++
+	}
+	return nil
+}
+
+// IsPurgeable: Pronto para exclusão definitiva.
+func (p *Pack) IsPurgeable(now time.Time) bool {
+	return isPurgeable(p.IsDeleted, p.DeletedAt, now)
+}
+
+// PreparePackPurge ajusta os contadores que dependem dos vínculos apagados no Purge.
+// O Job em seguida remove: PackSticker, MakerPackFavorite, MakerPackLike, PackKeyword e PackReview.
+// forks: remixes que apontam para o pack (IDPackSource); perdem a linhagem junto com a origem.
+func PreparePackPurge(p *Pack, owner *Maker, keywords []*Keyword, forks []*Pack) {
+	owner.LikesReceivedCount = subtractFloor(owner.LikesReceivedCount, p.LikesCount)
+	for _, k := range keywords {
+		decrement(&k.UsageCount)
+	}
+	for _, f := range forks {
+		f.IDPackSource = nil
+	}
+}
+
+// subtractFloor: a - b sem underflow.
+func subtractFloor(a, b uint64) uint64 {
+	if b > a {
+		return 0
+	}
+	return a - b
+}
+
+// ==========================================================
+// 4. DTOs (Lixeira do Maker)
+// ==========================================================
+
+type TrashItemResponse struct {
+	ID        int64      `json:"id"`
+	Type      TargetType `json:"type"`      // TargetSticker ou TargetPack
+	Title     string     `json:"title"`     // Nome do pack (vazio para sticker)
+	ImageURL  string     `json:"image_url"` // Thumb do sticker ou tray do pack
+	DeletedAt time.Time  `json:"deleted_at"`
+	PurgeAt   time.Time  `json:"purge_at"` // Último momento para restaurar
+}
+
+type TrashResponse struct {
+	Stickers []TrashItemResponse `json:"stickers"`
+	Packs    []TrashItemResponse `json:"packs"`
+}
+
+// Mapper
+func (s *Sticker) ToTrashResponse() TrashItemResponse {
+	var deletedAt time.Time
+	if s.DeletedAt != nil {
+		deletedAt = *s.DeletedAt
+	}
+	return TrashItemResponse{
+		ID:        s.ID,
+		Type:      TargetSticker,
+		ImageURL:  s.ImageThumbURL,
+		DeletedAt: deletedAt,
+		PurgeAt:   deletedAt.Add(TrashRetention),
+	}
+}
+
+// Mapper
+func (p *Pack) ToTrashResponse() TrashItemResponse {
+	var deletedAt time.Time
+	if p.DeletedAt != nil {
+		deletedAt = *p.DeletedAt
+	}
+	return TrashItemResponse{
+		ID:        p.ID,
+		Type:      TargetPack,
+		Title:     p.Name,
+		ImageURL:  p.TrayImageURL,
+		DeletedAt: deletedAt,
+		PurgeAt:   deletedAt.Add(TrashRetention),
+	}
+}
+
+/*
+REGRAS DA LIXEIRA:
+1.  Soft Delete: Deletar marca 'IsDeleted'/'DeletedAt'. O item some do app, mas os vínculos ficam guardados.
+2.  Lixeira: Cada Maker vê apenas os próprios itens deletados (IDMaker), com a data limite de restauração.
+3.  Restore: Permitido enquanto 'DeletedAt' estiver dentro de 'TrashRetention' (30 dias).
+4.  Packs Publicados: Deletar despublica primeiro. Restaurar devolve o pack como Despublicado.
+5.  Contadores no Delete/Restore: Maker.StickersCreatedCount, Anime.StickersCount, Pack.StickersCount e Sticker.PacksCount
+    saem no Delete e voltam no Restore. Nunca são ajustados duas vezes.
+    Pack.StickersSize acompanha o tamanho do sticker e o ContentRating do pack é recalculado sem (ou com) ele.
+    Remix na lixeira sai do ForksCount da origem e volta no Restore.
+6.  Purge: Job diário apaga definitivamente itens com 'IsPurgeable'. Antes de apagar os vínculos,
+    chama PrepareStickerPurge/PreparePackPurge (LikesReceivedCount do dono, UsageCount das keywords e 'IDPackSource' dos remixes).
+7.  Vínculos Purgados: PackSticker, Favoritos, Likes, pivots de Keyword (e PackReview, no caso de packs).
+8.  Mínimo de Stickers: Deletar um sticker que deixaria um pack publicado com menos de ExportMinStickers é recusado
+    (ErrPackBelowMinimum). O dono troca o sticker ou despublica o pack antes.
+*/
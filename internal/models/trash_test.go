@@ -0,0 +1,190 @@
+package models
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestStickerSoftDelete(t *testing.T) {
+	now := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name       string
+		status     PackStatus
+		count      uint64
+		rating     ContentRating
+		wantErr    error
+		wantCount  uint64
+		wantSize   float64
+		wantRating ContentRating
+	}{
+		{"rascunho pode ficar abaixo do mínimo", PackDraft, 3, RatingAdult, nil, 2, 2000, RatingSuggestive},
+		{"publicado acima do mínimo", PackPublished, 4, RatingAdult, nil, 3, 2000, RatingSuggestive},
+		{"publicado no mínimo é recusado", PackPublished, 3, RatingAdult, ErrPackBelowMinimum, 3, 3000, RatingAdult},
+		{"sticker safe não rebaixa o pack", PackPublished, 4, RatingSafe, nil, 3, 2000, RatingSuggestive},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			stickers := exportStickers(3)
+			stickers[1].ContentRating = RatingSuggestive
+			stickers[2].ContentRating = tc.rating
+			target := &stickers[2]
+			p := &Pack{ID: 1, Status: tc.status, StickersCount: tc.count, StickersSize: 3000}
+			p.RecomputeRating(stickers)
+			maker := &Maker{StickersCreatedCount: 1}
+			anime := &Anime{StickersCount: 1}
+			packStickers := map[int64][]Sticker{1: stickers}
+
+			err := target.SoftDelete(maker, anime, []*Pack{p}, packStickers, now)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tc.wantErr)
+			}
+			if p.StickersCount != tc.wantCount || p.StickersSize != tc.wantSize {
+				t.Errorf("pack = %d/%v, want %d/%v", p.StickersCount, p.StickersSize, tc.wantCount, tc.wantSize)
+			}
+			if p.ContentRating != tc.wantRating && tc.wantErr == nil {
+				t.Errorf("ContentRating = %q, want %q", p.ContentRating, tc.wantRating)
+			}
+			if tc.wantErr != nil {
+				if target.IsDeleted || maker.StickersCreatedCount != 1 || anime.StickersCount != 1 {
+					t.Errorf("delete recusado não deve alterar nada")
+				}
+				return
+			}
+			if !target.IsDeleted || maker.StickersCreatedCount != 0 || anime.StickersCount != 0 {
+				t.Errorf("contadores de Maker/Anime não desceram")
+			}
+		})
+	}
+}
+
+func TestStickerRestore(t *testing.T) {
+	deletedAt := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name    string
+		now     time.Time
+		deleted bool
+		wantErr error
+	}{
+		{"dentro da retenção", deletedAt.Add(24 * time.Hour), true, nil},
+		{"retenção expirada", deletedAt.Add(TrashRetention + time.Hour), true, ErrRetentionExpired},
+		{"fora da lixeira", deletedAt, false, ErrNotInTrash},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			stickers := exportStickers(3)
+			stickers[2].ContentRating = RatingAdult
+			target := stickers[2]
+			target.IsDeleted = tc.deleted
+			if tc.deleted {
+				target.DeletedAt = &deletedAt
+			}
+			stickers[2] = target
+			p := &Pack{ID: 1, Status: PackDraft, StickersCount: 2, StickersSize: 2000}
+			maker := &Maker{}
+
+			err := target.Restore(maker, nil, []*Pack{p}, map[int64][]Sticker{1: stickers}, tc.now)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tc.wantErr)
+			}
+			if tc.wantErr != nil {
+				return
+			}
+			if p.StickersCount != 3 || p.StickersSize != 3000 || maker.StickersCreatedCount != 1 {
+				t.Errorf("pack = %d/%v maker = %d", p.StickersCount, p.StickersSize, maker.StickersCreatedCount)
+			}
+			if p.ContentRating != RatingAdult {
+				t.Errorf("ContentRating = %q, want adult", p.ContentRating)
+			}
+		})
+	}
+}
+
+func TestPackSoftDeleteRestore(t *testing.T) {
+	now := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+	srcID := int64(9)
+	cases := []struct {
+		name       string
+		source     *Pack
+		wantForks  uint64
+		afterForks uint64
+	}{
+		{"pack autoral", nil, 0, 0},
+		{"remix desconta a origem", &Pack{ID: srcID, ForksCount: 2}, 1, 2},
+		{"origem já zerada", &Pack{ID: srcID}, 0, 1},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			stickers := []*Sticker{{ID: 1, PacksCount: 2}, {ID: 2, PacksCount: 0}}
+			p := &Pack{ID: 1, Status: PackDraft}
+			if tc.source != nil {
+				p.IDPackSource = &srcID
+			}
+
+			if err := p.SoftDelete(&Maker{}, nil, false, tc.source, stickers, now); err != nil {
+				t.Fatalf("SoftDelete: %v", err)
+			}
+			if err := p.SoftDelete(&Maker{}, nil, false, tc.source, stickers, now); !errors.Is(err, ErrAlreadyInTrash) {
+				t.Errorf("segundo SoftDelete = %v, want ErrAlreadyInTrash", err)
+			}
+			if stickers[0].PacksCount != 1 || stickers[1].PacksCount != 0 {
+				t.Errorf("PacksCount = %d/%d, want 1/0", stickers[0].PacksCount, stickers[1].PacksCount)
+			}
+			if tc.source != nil && tc.source.ForksCount != tc.wantForks {
+				t.Errorf("ForksCount após delete = %d, want %d", tc.source.ForksCount, tc.wantForks)
+			}
+			if err := p.Restore(tc.source, stickers, now.Add(time.Hour)); err != nil {
+				t.Fatalf("Restore: %v", err)
+			}
+			if stickers[0].PacksCount != 2 || p.IsDeleted {
+				t.Errorf("Restore não devolveu o estado")
+			}
+			if tc.source != nil && tc.source.ForksCount != tc.afterForks {
+				t.Errorf("ForksCount após restore = %d, want %d", tc.source.ForksCount, tc.afterForks)
+			}
+		})
+	}
+}
+
+func TestPreparePackPurge(t *testing.T) {
+	srcID := int64(1)
+	p := &Pack{ID: srcID, LikesCount: 5}
+	owner := &Maker{LikesReceivedCount: 3}
+	keywords := []*Keyword{{ID: 1, UsageCount: 2}, {ID: 2}}
+	forks := []*Pack{{ID: 2, IDPackSource: &srcID}, {ID: 3, IDPackSource: &srcID}}
+
+	PreparePackPurge(p, owner, keywords, forks)
+	if owner.LikesReceivedCount != 0 {
+		t.Errorf("LikesReceivedCount = %d, want 0", owner.LikesReceivedCount)
+	}
+	if keywords[0].UsageCount != 1 || keywords[1].UsageCount != 0 {
+		t.Errorf("UsageCount = %d/%d, want 1/0", keywords[0].UsageCount, keywords[1].UsageCount)
+	}
+	for _, f := range forks {
+		if f.IDPackSource != nil {
+			t.Errorf("fork %d ainda aponta para a origem purgada", f.ID)
+		}
+	}
+}
+
+func TestIsPurgeable(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	old := now.Add(-TrashRetention - time.Hour)
+	recent := now.Add(-time.Hour)
+	cases := []struct {
+		name      string
+		deleted   bool
+		deletedAt *time.Time
+		want      bool
+	}{
+		{"expirado", true, &old, true},
+		{"recente", true, &recent, false},
+		{"ativo", false, nil, false},
+	}
+	for _, tc := range cases {
+		s := &Sticker{IsDeleted: tc.deleted, DeletedAt: tc.deletedAt}
+		if got := s.IsPurgeable(now); got != tc.want {
+			t.Errorf("%s: IsPurgeable = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
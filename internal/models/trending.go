@@ -0,0 +1,167 @@
+package models
+
+import (
+	"otamaker-api/internal/constants"
+	"sort"
+	"time"
+)
+
+// ==========================================================
+// 1. HISTÓRICO DE BUSCAS (Baldes por Hora)
+// ==========================================================
+
+// KeywordSearchBucket: Quantas vezes a keyword foi buscada em uma hora.
+// 'Keyword.SearchCount' é o total histórico; a tendência é calculada sobre estes baldes.
+// Upsert: ON CONFLICT (id_keyword, hour) DO UPDATE SET count = count + EXCLUDED.count.
+type KeywordSearchBucket struct {
+	IDKeyword int64     `json:"id_keyword" db:"id_keyword" gorm:"primaryKey"`
+	Hour      time.Time `json:"hour" db:"hour" gorm:"primaryKey;index"` // Truncado na hora (UTC).
+	Count     uint64    `json:"count" db:"count"`
+}
+
+// SearchBucketRetention: Baldes mais antigos podem ser apagados pelo Job de limpeza.
+const SearchBucketRetention = 30 * 24 * time.Hour
+
+// SearchHour: Hora do balde (UTC, truncada).
+func SearchHour(t time.Time) time.Time {
+	return t.UTC().Truncate(time.Hour)
+}
+
+// ==========================================================
+// 2. TENDÊNCIA (Janela Atual vs Janela Anterior)
+// ==========================================================
+
+// TrendWindow: Tamanho da janela deslizante.
+type TrendWindow string
+
+const (
+	TrendHour TrendWindow = "1h"
+	TrendDay  TrendWindow = "24h"
+	TrendWeek TrendWindow = "7d"
+)
+
+var trendDurations = map[TrendWindow]time.Duration{
+	TrendHour: time.Hour,
+	TrendDay:  24 * time.Hour,
+	TrendWeek: 7 * 24 * time.Hour,
+}
+
+const (
+	// TrendMinSearches: Mínimo de buscas na janela atual (evita "tendência" de 1 busca).
+	TrendMinSearches = 5
+	TrendDefaultSize = 10
+)
+
+// Duration: Janela em tempo (padrão 24h).
+func (w TrendWindow) Duration() time.Duration {
+	if d, ok := trendDurations[w]; ok {
+		return d
+	}
+	return trendDurations[TrendDay]
+}
+
+// TrendingQuery: Query string do endpoint de tendências.
+type TrendingQuery struct {
+	Window TrendWindow `form:"window" binding:"omitempty,oneof=1h 24h 7d"`
+	Limit  int         `form:"limit" binding:"omitempty,min=1,max=50"`
+}
+
+// TrendingKeyword: Resultado do cálculo (antes de mapear para o idioma do cliente).
+type TrendingKeyword struct {
+	IDKeyword int64
+	Current   uint64  // Buscas na janela atual.
+	Previous  uint64  // Buscas na janela anterior (mesmo tamanho).
+	Growth    float64 // (Current+1)/(Previous+1). > 1 = subindo.
+}
+
+type TrendingKeywordResponse struct {
+	Keyword  KeywordResponse `json:"keyword"`
+	Current  uint64          `json:"current"`
+	Previous uint64          `json:"previous"`
+	Growth   float64         `json:"growth"`
+}
+
+// TrendRange: Intervalo [from, now) que o Service deve carregar (janela atual + anterior).
+func TrendRange(window TrendWindow, now time.Time) (from, to time.Time) {
+	d := window.Duration()
+	to = SearchHour(now).Add(time.Hour) // Inclui a hora corrente (parcial).
+	return to.Add(-2 * d), to
+}
+
+// ComputeTrending: Buscas em ascensão, não as mais buscadas de todos os tempos.
+// Compara a janela atual com a anterior de mesmo tamanho; a suavização (+1) evita divisão por zero
+// e impede que uma keyword nova com poucas buscas domine a lista.
+func ComputeTrending(buckets []KeywordSearchBucket, window TrendWindow, now time.Time, limit int) []TrendingKeyword {
+	from, to := TrendRange(window, now)
+	split := to.Add(-window.Duration())
+
+	byKeyword := map[int64]*TrendingKeyword{}
+	for _, b := range buckets {
+		if b.Hour.Before(from) || !b.Hour.Before(to) {
+			continue
+		}
+		t, ok := byKeyword[b.IDKeyword]
+		if !ok {
+			t = &TrendingKeyword{IDKeyword: b.IDKeyword}
+			byKeyword[b.IDKeyword] = t
+		}
+		if b.Hour.Before(split) {
+			t.Previous += b.Count
+		} else {
+			t.Current += b.Count
+		}
+	}
+
+	out := make([]TrendingKeyword, 0, len(byKeyword))
+	for _, t := range byKeyword {
+		if t.Current < TrendMinSearches || t.Current <= t.Previous {
+			continue
+		}
+		t.Growth = float64(t.Current+1) / float64(t.Previous+1)
+		out = append(out, *t)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Growth != out[j].Growth {
+			return out[i].Growth > out[j].Growth
+		}
+		if out[i].Current != out[j].Current {
+			return out[i].Current > out[j].Current
+		}
+		return out[i].IDKeyword < out[j].IDKeyword
+	})
+
+	if limit <= 0 {
+		limit = TrendDefaultSize
+	}
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out
+}
+
+// BuildTrendingResponses: keywords carregadas em lote (chave = ID). Keywords pendentes não aparecem.
+func BuildTrendingResponses(trends []TrendingKeyword, keywords map[int64]Keyword, lang constants.Language) []TrendingKeywordResponse {
+	out := make([]TrendingKeywordResponse, 0, len(trends))
+	for _, t := range trends {
+		k, ok := keywords[t.IDKeyword]
+		if !ok || k.IsPending {
+			continue
+		}
+		out = append(out, TrendingKeywordResponse{
+			Keyword:  k.ToResponse(lang),
+			Current:  t.Current,
+			Previous: t.Previous,
+			Growth:   t.Growth,
+		})
+	}
+	return out
+}
+
+/*
+REGRAS DE TENDÊNCIAS:
+1.  Registro: Cada busca incrementa 'Keyword.SearchCount' (histórico) e o balde da hora corrente (KeywordSearchBucket).
+2.  Janela: A tendência compara a janela atual (1h, 24h ou 7d) com a anterior de mesmo tamanho. Nunca usa o total histórico.
+3.  Ascensão: Só entra quem cresceu (Current > Previous) e teve pelo menos 5 buscas na janela atual.
+4.  Curadoria: Keywords pendentes não aparecem nas tendências.
+5.  Limpeza: Baldes com mais de 30 dias podem ser apagados por Job.
+*/
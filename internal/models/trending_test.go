@@ -0,0 +1,65 @@
+package models
+
+import (
+	"otamaker-api/internal/constants"
+	"testing"
+	"time"
+)
+
+func TestComputeTrending(t *testing.T) {
+	now := time.Date(2025, 5, 10, 14, 30, 0, 0, time.UTC)
+	hour := SearchHour(now)
+	bucket := func(id int64, hoursAgo int, count uint64) KeywordSearchBucket {
+		return KeywordSearchBucket{IDKeyword: id, Hour: hour.Add(-time.Duration(hoursAgo) * time.Hour), Count: count}
+	}
+	buckets := []KeywordSearchBucket{
+		bucket(1, 0, 10), bucket(1, 30, 2), // Subindo forte.
+		bucket(2, 5, 20), bucket(2, 25, 10), // Subindo menos.
+		bucket(3, 1, 4),                    // Abaixo do mínimo.
+		bucket(4, 2, 8), bucket(4, 26, 30), // Caindo.
+		bucket(5, 3, 100), bucket(5, 60, 999), // Balde fora das janelas.
+	}
+	cases := []struct {
+		name   string
+		window TrendWindow
+		limit  int
+		want   []int64
+	}{
+		{"24h", TrendDay, 0, []int64{5, 1, 2}},
+		{"limite", TrendDay, 2, []int64{5, 1}},
+		{"1h", TrendHour, 0, []int64{1}},
+		{"janela desconhecida = 24h", "2d", 0, []int64{5, 1, 2}},
+	}
+	for _, tc := range cases {
+		got := ComputeTrending(buckets, tc.window, now, tc.limit)
+		if len(got) != len(tc.want) {
+			t.Errorf("%s: %+v, want %v", tc.name, got, tc.want)
+			continue
+		}
+		for i, id := range tc.want {
+			if got[i].IDKeyword != id {
+				t.Errorf("%s: [%d] = %d, want %d", tc.name, i, got[i].IDKeyword, id)
+			}
+		}
+	}
+}
+
+func TestTrendRange(t *testing.T) {
+	now := time.Date(2025, 5, 10, 14, 30, 0, 0, time.UTC)
+	from, to := TrendRange(TrendHour, now)
+	if !to.Equal(time.Date(2025, 5, 10, 15, 0, 0, 0, time.UTC)) || !from.Equal(time.Date(2025, 5, 10, 13, 0, 0, 0, time.UTC)) {
+		t.Errorf("intervalo = [%v, %v)", from, to)
+	}
+}
+
+func TestBuildTrendingResponses(t *testing.T) {
+	trends := []TrendingKeyword{{IDKeyword: 1, Current: 10}, {IDKeyword: 2, Current: 8}, {IDKeyword: 3, Current: 6}}
+	keywords := map[int64]Keyword{
+		1: {ID: 1, Slug: "crying", Name: map[string]string{"pt_br": "Chorando"}},
+		2: {ID: 2, Slug: "novo", IsPending: true},
+	}
+	out := BuildTrendingResponses(trends, keywords, constants.PT_BR)
+	if len(out) != 1 || out[0].Keyword.Name != "Chorando" || out[0].Current != 10 {
+		t.Errorf("out = %+v", out)
+	}
+}
@@ -0,0 +1,178 @@
+package models
+
+import "time"
+
+// ==========================================================
+// 1. TRIAGEM DE QUALIDADE (Pack.Triage)
+// ==========================================================
+
+// Pesos de cada regra na nota final (soma = 100).
+// Nota interna: 0 (péssimo) a 100 (pronto para destaque).
+const (
+	triageWeightStickerCount = 20
+	triageWeightThumbnails   = 10
+	triageWeightDimensions   = 15
+	triageWeightEmojis       = 15
+	triageWeightKeywords     = 15
+	triageWeightDuplicates   = 15
+	triageWeightCreator      = 10
+)
+
+// Faixa ideal de stickers (Regra 5 do Anime).
+const (
+	TriageIdealMinStickers = 20
+	TriageIdealMaxStickers = 30
+)
+
+// TriageBreakdown: Nota detalhada por regra (cada item de 0 a 1).
+// Exposto apenas para Admins. O público vê somente o resultado em 'Pack.Triage'.
+type TriageBreakdown struct {
+	StickerCount   float32 `json:"sticker_count"`   // 20-30 stickers = 1.
+	Thumbnails     float32 `json:"thumbnails"`      // % de stickers com miniatura.
+	Dimensions     float32 `json:"dimensions"`      // % de stickers na dimensão predominante.
+	Emojis         float32 `json:"emojis"`          // % de stickers com emoji.
+	Keywords       float32 `json:"keywords"`        // Cobertura e formato dos slugs.
+	Duplicates     float32 `json:"duplicates"`      // 1 - taxa de imagens repetidas.
+	CreatorHistory float32 `json:"creator_history"` // Penaliza strikes de moderação do dono.
+	Total          float32 `json:"total"`           // Nota final (0-100).
+}
+
+// ComputeTriage calcula a nota do pack a partir dos stickers referenciados (PackSticker)
+// e da quantidade de strikes do criador (Moderation.IsStrike).
+func ComputeTriage(p *Pack, stickers []Sticker, creatorStrikes int) TriageBreakdown {
+	var b TriageBreakdown
+
+	b.StickerCount = triageStickerCount(len(stickers))
+	b.Keywords = triageKeywords(p.Keywords, stickers)
+	b.CreatorHistory = 1 / float32(1+creatorStrikes)
+
+	if n := len(stickers); n > 0 {
+		var thumbs, emojis int
+		dims := make(map[[2]int]int, 2)
+		images := make(map[string]struct{}, n)
+		for _, s := range stickers {
+			if s.ImageThumbURL != "" {
+				thumbs++
+			}
+			if len(s.Emojis) > 0 {
+				emojis++
+			}
+			dims[[2]int{s.Width, s.Height}]++
+			images[s.ImageURL] = struct{}{}
+		}
+
+		var mode int
+		for _, count := range dims {
+			if count > mode {
+				mode = count
+			}
+		}
+
+		b.Thumbnails = float32(thumbs) / float32(n)
+		b.Emojis = float32(emojis) / float32(n)
+		b.Dimensions = float32(mode) / float32(n)
+		b.Duplicates = float32(len(images)) / float32(n)
+	}
+
+	b.Total = b.StickerCount*triageWeightStickerCount +
+		b.Thumbnails*triageWeightThumbnails +
+		b.Dimensions*triageWeightDimensions +
+		b.Emojis*triageWeightEmojis +
+		b.Keywords*triageWeightKeywords +
+		b.Duplicates*triageWeightDuplicates +
+		b.CreatorHistory*triageWeightCreator
+
+	return b
+}
+
+// triageStickerCount: 1 dentro da faixa ideal, proporcional abaixo dela, 0 acima do limite do WA.
+func triageStickerCount(n int) float32 {
+	switch {
+	case n > TriageIdealMaxStickers:
+		return 0
+	case n >= TriageIdealMinStickers:
+		return 1
+	default:
+		return float32(n) / TriageIdealMinStickers
+	}
+}
+
+// triageKeywords: Média entre "pack tem tags", "tags são slugs válidos" e "stickers têm tags".
+func triageKeywords(packKeywords []string, stickers []Sticker) float32 {
+	var hasTags, validTags, stickerCoverage float32
+
+	if len(packKeywords) > 0 {
+		hasTags = 1
+		var valid int
+		for _, k := range packKeywords {
+			if IsSlug(k) {
+				valid++
+			}
+		}
+		validTags = float32(valid) / float32(len(packKeywords))
+	}
+
+	if len(stickers) > 0 {
+		var tagged int
+		for _, s := range stickers {
+			if len(s.Keywords) > 0 {
+				tagged++
+			}
+		}
+		stickerCoverage = float32(tagged) / float32(len(stickers))
+	}
+
+	return (hasTags + validTags + stickerCoverage) / 3
+}
+
+// ApplyTriage grava a nota e o detalhamento no Pack.
+func (p *Pack) ApplyTriage(b TriageBreakdown, now time.Time) {
+	total := b.Total
+	p.Triage = &total
+	p.TriageBreakdown = &b
+	p.TriagedAt = &now
+}
+
+// TouchContent: Registra uma edição de conteúdo (nome, tags, lista de stickers).
+// Mudanças de status (publicar, despublicar) atualizam só 'UpdatedAt' e não invalidam a triagem.
+func (p *Pack) TouchContent(now time.Time) {
+	p.UpdatedAt = now
+	p.ContentUpdatedAt = &now
+}
+
+// TriageStale: True se o conteúdo mudou depois da última triagem (ou nunca foi triado).
+// O Service/Job usa isso para reprocessar apenas o necessário.
+func (p *Pack) TriageStale() bool {
+	return p.TriagedAt == nil || (p.ContentUpdatedAt != nil && p.ContentUpdatedAt.After(*p.TriagedAt))
+}
+
+// ==========================================================
+// 2. DTO (Admin)
+// ==========================================================
+
+type PackTriageResponse struct {
+	IDPack    int64            `json:"id_pack"`
+	Triage    *float32         `json:"triage"`
+	Breakdown *TriageBreakdown `json:"breakdown"`
+	TriagedAt *time.Time       `json:"triaged_at"`
+}
+
+// Mapper (Admin)
+func (p *Pack) ToTriageResponse() PackTriageResponse {
+	return PackTriageResponse{
+		IDPack:    p.ID,
+		Triage:    p.Triage,
+		Breakdown: p.TriageBreakdown,
+		TriagedAt: p.TriagedAt,
+	}
+}
+
+/*
+REGRAS DE TRIAGEM:
+1.  Automática: A nota é calculada por regras fixas, sem intervenção humana. Admins apenas consultam o detalhamento.
+2.  Gatilho: Toda criação/edição de conteúdo do pack (nome, tags, lista de stickers) passa por 'TouchContent' e torna a triagem obsoleta (TriageStale).
+    Mudanças de status não contam: despublicar e republicar não manda o pack de volta para a curadoria.
+3.  Reprocessamento: O Service recalcula na hora da alteração; um Job varre packs com 'TriageStale' como rede de segurança.
+4.  Histórico do Criador: Cada strike (Moderation resolvida com ação) reduz o peso do critério de criador.
+5.  Privacidade: 'TriageBreakdown' nunca é exposto em endpoints públicos.
+*/
@@ -0,0 +1,99 @@
+package models
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func triageStickers(n int) []Sticker {
+	out := make([]Sticker, n)
+	for i := range out {
+		out[i] = Sticker{
+			ImageURL:      fmt.Sprintf("https://cdn/%d.webp", i),
+			ImageThumbURL: fmt.Sprintf("https://cdn/%d_t.webp", i),
+			Width:         512,
+			Height:        512,
+			Emojis:        []string{"😀"},
+			Keywords:      []string{"happy"},
+		}
+	}
+	return out
+}
+
+func TestComputeTriage(t *testing.T) {
+	noThumbs := triageStickers(20)
+	for i := range noThumbs {
+		noThumbs[i].ImageThumbURL = ""
+	}
+	duplicated := triageStickers(20)
+	for i := range duplicated {
+		duplicated[i].ImageURL = "https://cdn/same.webp"
+	}
+
+	cases := []struct {
+		name     string
+		keywords []string
+		stickers []Sticker
+		strikes  int
+		want     float32
+	}{
+		{"pack ideal", []string{"one_piece"}, triageStickers(20), 0, 100},
+		{"sem miniaturas perde o peso de miniatura", []string{"one_piece"}, noThumbs, 0, 90},
+		{"acima do limite do WhatsApp zera a quantidade", []string{"one_piece"}, triageStickers(31), 0, 80},
+		{"strike divide o peso do criador", []string{"one_piece"}, triageStickers(20), 1, 95},
+		{"imagens repetidas", []string{"one_piece"}, duplicated, 0, 85 + 15.0/20},
+		{"vazio só pontua o criador", nil, nil, 0, 10},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := ComputeTriage(&Pack{Keywords: tc.keywords}, tc.stickers, tc.strikes)
+			if diff := got.Total - tc.want; diff > 0.01 || diff < -0.01 {
+				t.Fatalf("Total = %v, want %v (%+v)", got.Total, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestTriageStickerCount(t *testing.T) {
+	cases := []struct {
+		n    int
+		want float32
+	}{
+		{0, 0}, {10, 0.5}, {20, 1}, {30, 1}, {31, 0},
+	}
+	for _, tc := range cases {
+		if got := triageStickerCount(tc.n); got != tc.want {
+			t.Errorf("triageStickerCount(%d) = %v, want %v", tc.n, got, tc.want)
+		}
+	}
+}
+
+func TestTriageStale(t *testing.T) {
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	t1 := t0.Add(time.Hour)
+
+	cases := []struct {
+		name string
+		run  func(p *Pack)
+		want bool
+	}{
+		{"nunca triado", func(p *Pack) { p.TriagedAt = nil }, true},
+		{"triado e intocado", func(p *Pack) {}, false},
+		{"conteúdo editado depois", func(p *Pack) { p.TouchContent(t1) }, true},
+		{"despublicar e republicar não invalida", func(p *Pack) {
+			p.setStatus(PackUnpublished, t1)
+			p.setStatus(PackPublished, t1.Add(time.Minute))
+		}, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := &Pack{Status: PackPublished}
+			p.ApplyTriage(TriageBreakdown{Total: 80}, t0)
+			tc.run(p)
+			if got := p.TriageStale(); got != tc.want {
+				t.Fatalf("TriageStale = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
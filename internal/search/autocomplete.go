@@ -0,0 +1,255 @@
+package search
+
+import (
+	"otamaker-api/internal/constants"
+	"otamaker-api/internal/models"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ==========================================================
+// 1. ÍNDICE DE SUGESTÕES (Keywords, Animes e Makers)
+// ==========================================================
+
+// Ordem de exibição dos grupos no autocomplete.
+var suggestGroupOrder = []string{
+	models.SuggestGroupAnime,
+	string(models.CategoryCharacter),
+	string(models.CategoryWork),
+	string(models.CategoryEmotion),
+	string(models.CategoryEvent),
+	string(models.CategoryGeneral),
+	string(models.CategoryArtist),
+	models.SuggestGroupMaker,
+}
+
+const (
+	SuggestDefaultLimit = 5
+	SuggestMaxLimit     = 10
+)
+
+// suggestKey: kind = "keyword", "anime" ou "maker" (a categoria da keyword pode mudar sem trocar a chave).
+type suggestKey struct {
+	kind string
+	id   int64
+}
+
+type suggestion struct {
+	key        suggestKey
+	group      string
+	slug       string
+	names      map[string]string // I18N (Keyword/Anime). Makers usam 'slug' (nickname).
+	image      *string
+	popularity uint64
+	rating     models.ContentRating
+}
+
+type suggestTerm struct {
+	term string
+	key  suggestKey
+}
+
+// Suggester: Prefixos de Slug, Nomes (todos os idiomas), Aliases e Nicknames.
+// Atualizado de forma incremental, como o Index.
+type Suggester struct {
+	mu      sync.RWMutex
+	entries map[suggestKey]*suggestion
+	terms   []suggestTerm // Sempre ordenado por 'term' (insertTerm), então Suggest só precisa do RLock.
+}
+
+func NewSuggester() *Suggester {
+	return &Suggester{entries: map[suggestKey]*suggestion{}}
+}
+
+// UpsertKeyword: Keywords pendentes não são sugeridas (aguardam curadoria).
+// Popularidade = UsageCount + SearchCount.
+func (sg *Suggester) UpsertKeyword(k *models.Keyword) {
+	key := suggestKey{"keyword", k.ID}
+	if k.IsPending {
+		sg.remove(key)
+		return
+	}
+	group := string(k.Category)
+	if group == "" {
+		group = string(models.CategoryGeneral)
+	}
+	sg.put(&suggestion{key: key, group: group, slug: k.Slug, names: k.Name, popularity: k.UsageCount + k.SearchCount},
+		append(append([]string{k.Slug}, mapValues(k.Name)...), k.Aliases...))
+}
+
+// UpsertAnime: Animes ocultos, moderados ou mesclados saem das sugestões. Popularidade = PacksCount.
+func (sg *Suggester) UpsertAnime(a *models.Anime) {
+	key := suggestKey{models.SuggestGroupAnime, a.ID}
+	if !a.IsVisible || a.IsModerated || a.MergedIntoID != nil {
+		sg.remove(key)
+		return
+	}
+	image := a.ImageCoverPreviewURL
+	sg.put(&suggestion{key: key, group: models.SuggestGroupAnime, names: a.Name, image: &image, popularity: a.PacksCount, rating: a.ContentRating},
+		mapValues(a.Name))
+}
+
+// UpsertMaker: Makers suspensos ou em shadowban não são sugeridos. Popularidade = seguidores (Real + Artificial).
+func (sg *Suggester) UpsertMaker(m *models.Maker) {
+	key := suggestKey{models.SuggestGroupMaker, m.IDAccount}
+	if m.IsSuspended != 0 {
+		sg.remove(key)
+		return
+	}
+	var names map[string]string
+	if m.Name != "" {
+		names = map[string]string{string(constants.Default): m.Name} // Nome de exibição não é traduzido.
+	}
+	sg.put(&suggestion{key: key, group: models.SuggestGroupMaker, slug: m.Nickname, names: names, image: m.AvatarPreviewURL, popularity: m.FollowersCount + m.ArtificialFollowers},
+		[]string{m.Nickname, m.Name})
+}
+
+// RemoveKeyword/RemoveAnime/RemoveMaker: Exclusão ou merge.
+func (sg *Suggester) RemoveKeyword(id int64) { sg.remove(suggestKey{"keyword", id}) }
+func (sg *Suggester) RemoveAnime(id int64)   { sg.remove(suggestKey{models.SuggestGroupAnime, id}) }
+func (sg *Suggester) RemoveMaker(id int64)   { sg.remove(suggestKey{models.SuggestGroupMaker, id}) }
+
+func (sg *Suggester) put(s *suggestion, texts []string) {
+	sg.mu.Lock()
+	defer sg.mu.Unlock()
+
+	sg.dropTerms(s.key)
+	sg.entries[s.key] = s
+	seen := map[string]bool{}
+	for _, text := range texts {
+		full := strings.Join(Tokenize(text), " ")
+		if full == "" {
+			continue
+		}
+		// Frase inteira + cada palavra: "titan" encontra "Attack on Titan".
+		for _, t := range append([]string{full}, strings.Fields(full)...) {
+			if !seen[t] {
+				seen[t] = true
+				sg.insertTerm(suggestTerm{term: t, key: s.key})
+			}
+		}
+	}
+}
+
+// insertTerm mantém 'terms' ordenado a cada escrita. Chamador segura o lock.
+func (sg *Suggester) insertTerm(t suggestTerm) {
+	i := sort.Search(len(sg.terms), func(i int) bool { return sg.terms[i].term > t.term })
+	sg.terms = append(sg.terms, suggestTerm{})
+	copy(sg.terms[i+1:], sg.terms[i:])
+	sg.terms[i] = t
+}
+
+func (sg *Suggester) remove(key suggestKey) {
+	sg.mu.Lock()
+	defer sg.mu.Unlock()
+	sg.dropTerms(key)
+	delete(sg.entries, key)
+}
+
+// dropTerms: Chamador segura o lock.
+func (sg *Suggester) dropTerms(key suggestKey) {
+	if _, ok := sg.entries[key]; !ok {
+		return
+	}
+	kept := sg.terms[:0]
+	for _, t := range sg.terms {
+		if t.key != key {
+			kept = append(kept, t)
+		}
+	}
+	sg.terms = kept
+}
+
+func mapValues(m map[string]string) []string {
+	out := make([]string, 0, len(m))
+	for _, v := range m {
+		out = append(out, v)
+	}
+	return out
+}
+
+// ==========================================================
+// 2. CONSULTA (Agrupada por Categoria)
+// ==========================================================
+
+// Suggest: Sugestões por prefixo, agrupadas (KeywordCategory, "anime", "maker").
+// Dentro do grupo: correspondência exata primeiro, depois popularidade.
+// ceiling: teto de classificação do usuário (animes acima dele não são sugeridos).
+func (sg *Suggester) Suggest(q string, lang constants.Language, ceiling models.ContentRating, limit int) models.AutocompleteResponse {
+	if limit <= 0 {
+		limit = SuggestDefaultLimit
+	}
+	if limit > SuggestMaxLimit {
+		limit = SuggestMaxLimit
+	}
+	prefix := strings.Join(Tokenize(q), " ")
+	resp := models.AutocompleteResponse{Groups: []models.AutocompleteGroup{}}
+	if prefix == "" {
+		return resp
+	}
+
+	sg.mu.RLock()
+	defer sg.mu.RUnlock()
+
+	exact := map[suggestKey]bool{}
+	matched := map[suggestKey]bool{}
+	i := sort.Search(len(sg.terms), func(i int) bool { return sg.terms[i].term >= prefix })
+	for ; i < len(sg.terms) && strings.HasPrefix(sg.terms[i].term, prefix); i++ {
+		t := sg.terms[i]
+		matched[t.key] = true
+		if t.term == prefix {
+			exact[t.key] = true
+		}
+	}
+
+	ceiling = ceiling.Normalize()
+	byGroup := map[string][]*suggestion{}
+	for key := range matched {
+		s := sg.entries[key]
+		if s == nil || !ceiling.Allows(s.rating) {
+			continue
+		}
+		byGroup[s.group] = append(byGroup[s.group], s)
+	}
+
+	for _, group := range suggestGroupOrder {
+		list := byGroup[group]
+		if len(list) == 0 {
+			continue
+		}
+		sort.Slice(list, func(i, j int) bool {
+			if exact[list[i].key] != exact[list[j].key] {
+				return exact[list[i].key]
+			}
+			if list[i].popularity != list[j].popularity {
+				return list[i].popularity > list[j].popularity
+			}
+			return list[i].key.id < list[j].key.id
+		})
+		if len(list) > limit {
+			list = list[:limit]
+		}
+
+		items := make([]models.AutocompleteItem, 0, len(list))
+		for _, s := range list {
+			name := lang.Get(s.names)
+			if name == "" {
+				name = strings.ReplaceAll(s.slug, "_", " ")
+			}
+			items = append(items, models.AutocompleteItem{ID: s.key.id, Slug: s.slug, Name: name, ImageURL: s.image})
+		}
+		resp.Groups = append(resp.Groups, models.AutocompleteGroup{Group: group, Items: items})
+	}
+	return resp
+}
+
+/*
+REGRAS DO AUTOCOMPLETE:
+1.  Fontes: Keywords curadas (slug, nomes traduzidos e aliases), Animes (nomes em todos os idiomas) e Makers (nickname e nome).
+2.  Grupos: Keywords agrupadas pela KeywordCategory; Animes e Makers em grupos próprios. Ordem fixa (suggestGroupOrder).
+3.  Ordenação: Correspondência exata primeiro; depois popularidade (Keyword: UsageCount + SearchCount; Anime: PacksCount; Maker: Seguidores).
+4.  Visibilidade: Keywords pendentes, animes ocultos/moderados/mesclados, animes acima da classificação do usuário e makers suspensos não aparecem.
+5.  Tendências: O endpoint de tendências usa baldes horários (trending.go), não o 'SearchCount' histórico.
+6.  Concorrência: Os termos são inseridos já ordenados dentro do lock de escrita; a consulta nunca vê a lista fora de ordem.
+*/
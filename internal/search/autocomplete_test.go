@@ -0,0 +1,105 @@
+package search
+
+import (
+	"otamaker-api/internal/constants"
+	"otamaker-api/internal/models"
+	"sort"
+	"sync"
+	"testing"
+)
+
+func newTestSuggester() *Suggester {
+	sg := NewSuggester()
+	sg.UpsertKeyword(&models.Keyword{ID: 1, Slug: "naruto_uzumaki", Category: models.CategoryCharacter, Name: map[string]string{"pt_br": "Naruto Uzumaki"}, UsageCount: 50})
+	sg.UpsertKeyword(&models.Keyword{ID: 2, Slug: "nami", Category: models.CategoryCharacter, UsageCount: 10})
+	sg.UpsertKeyword(&models.Keyword{ID: 3, Slug: "crying", Category: models.CategoryEmotion, Name: map[string]string{"pt_br": "Chorando"}})
+	sg.UpsertKeyword(&models.Keyword{ID: 4, Slug: "nap", IsPending: true})
+	sg.UpsertAnime(&models.Anime{ID: 10, IsVisible: true, Name: map[string]string{"pt_br": "Naruto"}, PacksCount: 3})
+	sg.UpsertAnime(&models.Anime{ID: 11, IsVisible: true, Name: map[string]string{"pt_br": "Nana"}, ContentRating: models.RatingAdult})
+	sg.UpsertMaker(&models.Maker{IDAccount: 7, Nickname: "narutofan", FollowersCount: 3})
+	sg.UpsertMaker(&models.Maker{IDAccount: 8, Nickname: "naraku", IsSuspended: 2})
+	return sg
+}
+
+func TestSuggest(t *testing.T) {
+	cases := []struct {
+		name    string
+		q       string
+		ceiling models.ContentRating
+		limit   int
+		want    map[string][]int64 // Grupo -> IDs na ordem.
+		order   []string
+	}{
+		{"agrupado", "na", models.RatingSafe, 0,
+			map[string][]int64{models.SuggestGroupAnime: {10}, "character": {1, 2}, models.SuggestGroupMaker: {7}},
+			[]string{models.SuggestGroupAnime, "character", models.SuggestGroupMaker}},
+		{"exato primeiro", "nami", models.RatingSafe, 0, map[string][]int64{"character": {2}}, []string{"character"}},
+		{"teto adulto", "nana", models.RatingAdult, 0, map[string][]int64{models.SuggestGroupAnime: {11}}, []string{models.SuggestGroupAnime}},
+		{"palavra do meio", "uzumaki", models.RatingSafe, 0, map[string][]int64{"character": {1}}, []string{"character"}},
+		{"nome traduzido", "chor", models.RatingSafe, 0, map[string][]int64{"emotion": {3}}, []string{"emotion"}},
+		{"limite por grupo", "na", models.RatingSafe, 1,
+			map[string][]int64{models.SuggestGroupAnime: {10}, "character": {1}, models.SuggestGroupMaker: {7}},
+			[]string{models.SuggestGroupAnime, "character", models.SuggestGroupMaker}},
+		{"vazio", "!!", models.RatingSafe, 0, map[string][]int64{}, nil},
+	}
+	sg := newTestSuggester()
+	for _, tc := range cases {
+		resp := sg.Suggest(tc.q, constants.PT_BR, tc.ceiling, tc.limit)
+		if len(resp.Groups) != len(tc.order) {
+			t.Errorf("%s: grupos = %+v, want %v", tc.name, resp.Groups, tc.order)
+			continue
+		}
+		for i, g := range resp.Groups {
+			if g.Group != tc.order[i] {
+				t.Errorf("%s: grupo %d = %q, want %q", tc.name, i, g.Group, tc.order[i])
+			}
+			want := tc.want[g.Group]
+			if len(g.Items) != len(want) {
+				t.Errorf("%s: %s = %+v, want %v", tc.name, g.Group, g.Items, want)
+				continue
+			}
+			for j, id := range want {
+				if g.Items[j].ID != id {
+					t.Errorf("%s: %s[%d] = %d, want %d", tc.name, g.Group, j, g.Items[j].ID, id)
+				}
+			}
+		}
+	}
+}
+
+func TestSuggesterUpdates(t *testing.T) {
+	sg := newTestSuggester()
+	sg.UpsertKeyword(&models.Keyword{ID: 2, Slug: "nami", IsPending: true})
+	sg.RemoveAnime(10)
+	sg.UpsertMaker(&models.Maker{IDAccount: 7, Nickname: "outro"})
+	resp := sg.Suggest("na", constants.PT_BR, models.RatingSafe, 0)
+	if len(resp.Groups) != 1 || resp.Groups[0].Group != "character" || len(resp.Groups[0].Items) != 1 {
+		t.Errorf("grupos = %+v, want só naruto_uzumaki", resp.Groups)
+	}
+	if !sort.SliceIsSorted(sg.terms, func(i, j int) bool { return sg.terms[i].term < sg.terms[j].term }) {
+		t.Error("termos fora de ordem após atualizações")
+	}
+}
+
+func TestSuggesterConcurrent(t *testing.T) {
+	sg := newTestSuggester()
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(2)
+		go func(id int64) {
+			defer wg.Done()
+			sg.UpsertKeyword(&models.Keyword{ID: 100 + id, Slug: "naruto_" + string(rune('a'+id)), Category: models.CategoryGeneral})
+		}(int64(i))
+		go func() {
+			defer wg.Done()
+			sg.Suggest("naruto", constants.PT_BR, models.RatingSafe, 0)
+		}()
+	}
+	wg.Wait()
+	resp := sg.Suggest("naruto_", constants.PT_BR, models.RatingSafe, SuggestMaxLimit)
+	for _, g := range resp.Groups {
+		if g.Group == "general" && len(g.Items) != SuggestMaxLimit {
+			t.Errorf("general = %d itens, want %d", len(g.Items), SuggestMaxLimit)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package search
+
+import "otamaker-api/internal/models"
+
+// FactorEmotion: Sticker encontrado pela emoção do emoji ("😢" -> "sad"), não pelo emoji em si.
+const FactorEmotion = 0.7
+
+// LookupEmojis: Sugestão de stickers para o teclado.
+// Cada emoji soma pontos (basta casar um). Emoji exato vale mais que a keyword de emoção.
+// Devolve também os emojis reconhecidos (normalizados) e os slugs de emoção usados.
+func (ix *Index) LookupEmojis(text string, opts Options) (Result, []string) {
+	emojis := models.NormalizeEmojis([]string{text})
+
+	opts.Kind = KindSticker
+	result := Result{Hits: []Hit{}}
+	if len(emojis) == 0 {
+		return result, []string{}
+	}
+
+	ix.mu.RLock()
+	relevance := map[docKey]float64{}
+	for _, e := range emojis {
+		scores := map[docKey]float64{}
+		for key, w := range ix.postings[e] {
+			scores[key] = w
+		}
+		for _, slug := range models.EmotionSlugs(e, opts.Resolver) {
+			result.Keywords = append(result.Keywords, slug)
+			for key, w := range ix.postings[slug] {
+				if w*FactorEmotion > scores[key] {
+					scores[key] = w * FactorEmotion
+				}
+			}
+		}
+		for key, s := range scores {
+			relevance[key] += s
+		}
+	}
+	hits := ix.rank(relevance, opts)
+	ix.mu.RUnlock()
+
+	return paginate(result, hits, opts), emojis
+}
@@ -0,0 +1,46 @@
+package search
+
+import (
+	"otamaker-api/internal/models"
+	"testing"
+)
+
+func TestLookupEmojis(t *testing.T) {
+	ix := NewIndex()
+	ix.UpsertSticker(&models.Sticker{ID: 1, IsVisible: true, Emojis: []string{"😢"}})
+	ix.UpsertSticker(&models.Sticker{ID: 2, IsVisible: true, Emojis: []string{"🙂"}, Keywords: []string{"sad"}})
+	ix.UpsertSticker(&models.Sticker{ID: 3, IsVisible: true, Emojis: []string{"👍🏽"}})
+	ix.UpsertSticker(&models.Sticker{ID: 4, IsVisible: true, Emojis: []string{"👩‍💻"}})
+	ix.UpsertSticker(&models.Sticker{ID: 5, IsVisible: true, Emojis: []string{"😢"}, ContentRating: models.RatingAdult})
+	ix.UpsertPack(&models.Pack{ID: 1, Status: models.PackPublished, Keywords: []string{"sad"}})
+
+	cases := []struct {
+		name       string
+		text       string
+		opts       Options
+		wantHits   []int64
+		wantEmojis []string
+	}{
+		{"exato antes da emoção", "😢", Options{}, []int64{1, 2}, []string{"😢"}},
+		{"tom de pele", "👍", Options{}, []int64{3}, []string{"👍"}},
+		{"consulta com tom", "👍🏿", Options{}, []int64{3}, []string{"👍"}},
+		{"componente ZWJ", "👩", Options{}, []int64{4}, []string{"👩"}},
+		{"teto adulto", "😢", Options{MaxRating: models.RatingAdult}, []int64{5, 1, 2}, []string{"😢"}},
+		{"sem emoji", "oi", Options{}, nil, []string{}},
+	}
+	for _, tc := range cases {
+		result, emojis := ix.LookupEmojis(tc.text, tc.opts)
+		if len(result.Hits) != len(tc.wantHits) {
+			t.Errorf("%s: hits = %+v, want %v", tc.name, result.Hits, tc.wantHits)
+			continue
+		}
+		for i, id := range tc.wantHits {
+			if result.Hits[i].ID != id || result.Hits[i].Kind != KindSticker {
+				t.Errorf("%s: hits[%d] = %+v, want sticker %d", tc.name, i, result.Hits[i], id)
+			}
+		}
+		if len(emojis) != len(tc.wantEmojis) || (len(emojis) > 0 && emojis[0] != tc.wantEmojis[0]) {
+			t.Errorf("%s: emojis = %q, want %q", tc.name, emojis, tc.wantEmojis)
+		}
+	}
+}
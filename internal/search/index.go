@@ -0,0 +1,245 @@
+package search
+
+import (
+	"otamaker-api/internal/models"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// ==========================================================
+// 1. ÍNDICE INVERTIDO (Em memória, por processo)
+// ==========================================================
+
+// DocKind: Tipo do documento indexado.
+type DocKind string
+
+const (
+	KindSticker DocKind = "sticker"
+	KindPack    DocKind = "pack"
+)
+
+// Pesos por campo. Keyword/Emoji são a intenção do Maker; descrição é texto livre.
+const (
+	WeightKeyword     float64 = 3
+	WeightEmoji       float64 = 3
+	WeightName        float64 = 2
+	WeightAnime       float64 = 2
+	WeightDescription float64 = 1
+)
+
+type docKey struct {
+	Kind DocKind
+	ID   int64
+}
+
+// doc: Dados mínimos para ranquear e filtrar sem ir ao banco.
+type doc struct {
+	key       docKey
+	idAnime   int64
+	terms     map[string]float64 // Termo -> maior peso entre os campos.
+	downloads uint64
+	likes     uint64
+	rating    models.ContentRating
+}
+
+// Index: Índice invertido de Stickers e Packs.
+// Nomes de anime (todos os idiomas) ficam num índice à parte e valem para todos os docs do anime,
+// assim renomear um anime não exige reindexar os stickers dele.
+type Index struct {
+	mu sync.RWMutex
+
+	docs     map[docKey]*doc
+	postings map[string]map[docKey]float64
+
+	animeTerms    map[int64]map[string]float64 // Anime -> termos
+	animePostings map[string]map[int64]float64 // Termo -> animes
+	animeDocs     map[int64]map[docKey]bool    // Anime -> docs
+
+	vocab *vocabulary
+}
+
+func NewIndex() *Index {
+	return &Index{
+		docs:          map[docKey]*doc{},
+		postings:      map[string]map[docKey]float64{},
+		animeTerms:    map[int64]map[string]float64{},
+		animePostings: map[string]map[int64]float64{},
+		animeDocs:     map[int64]map[docKey]bool{},
+		vocab:         newVocabulary(),
+	}
+}
+
+// ==========================================================
+// 2. TOKENIZAÇÃO
+// ==========================================================
+
+// Tokenize quebra texto livre em termos normalizados (minúsculas, sem acento).
+// Emojis e outros símbolos são descartados aqui; o campo Emojis é indexado à parte.
+func Tokenize(text string) []string {
+	folded := models.NormalizeTerm(text)
+	return strings.FieldsFunc(folded, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// slugTerms: "naruto_uzumaki" -> ["naruto_uzumaki", "naruto", "uzumaki"].
+func slugTerms(slug string) []string {
+	parts := strings.Split(slug, "_")
+	if len(parts) == 1 {
+		return parts
+	}
+	return append([]string{slug}, parts...)
+}
+
+func addTerms(terms map[string]float64, weight float64, values ...string) {
+	for _, t := range values {
+		if t != "" && terms[t] < weight {
+			terms[t] = weight
+		}
+	}
+}
+
+// ==========================================================
+// 3. ATUALIZAÇÃO INCREMENTAL
+// ==========================================================
+
+// UpsertSticker indexa (ou reindexa) o sticker. Stickers não públicos são removidos.
+// Chamado na criação, edição e em toda ação de moderação.
+func (ix *Index) UpsertSticker(s *models.Sticker) {
+	key := docKey{KindSticker, s.ID}
+	if !s.IsVisible || s.IsDeleted || s.IsModerated {
+		ix.Remove(KindSticker, s.ID)
+		return
+	}
+
+	terms := map[string]float64{}
+	for _, slug := range s.Keywords {
+		addTerms(terms, WeightKeyword, slugTerms(slug)...)
+	}
+	// 'Sticker.Emojis' guarda o que o Maker enviou; o índice usa a forma normalizada.
+	for _, e := range models.NormalizeEmojis(s.Emojis) {
+		// Sequência ZWJ também responde pelo primeiro componente ("👩‍💻" aparece em "👩").
+		addTerms(terms, WeightEmoji*FactorTypo, models.EmojiBase(e))
+		addTerms(terms, WeightEmoji, e)
+	}
+
+	d := &doc{key: key, terms: terms, downloads: s.DownloadsCount, likes: s.LikesCount, rating: s.ContentRating}
+	if s.IDAnime != nil {
+		d.idAnime = *s.IDAnime
+	}
+	ix.put(d)
+}
+
+// UpsertPack indexa (ou reindexa) o pack. Só packs publicados e não banidos ficam no índice.
+func (ix *Index) UpsertPack(p *models.Pack) {
+	key := docKey{KindPack, p.ID}
+	if !p.IsPublished() || p.IsDeleted || p.IDModerationBanned != nil {
+		ix.Remove(KindPack, p.ID)
+		return
+	}
+
+	terms := map[string]float64{}
+	addTerms(terms, WeightDescription, Tokenize(p.Description)...)
+	addTerms(terms, WeightName, Tokenize(p.Name)...)
+	for _, slug := range p.Keywords {
+		addTerms(terms, WeightKeyword, slugTerms(slug)...)
+	}
+
+	ix.put(&doc{key: key, idAnime: p.IDAnime, terms: terms, downloads: p.DownloadsCount, likes: p.LikesCount, rating: p.ContentRating})
+}
+
+// UpsertAnime indexa os nomes do anime em todos os idiomas. Anime oculto/moderado sai do índice de nomes.
+func (ix *Index) UpsertAnime(a *models.Anime) {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+
+	ix.dropAnimeTerms(a.ID)
+	if !a.IsVisible || a.IsModerated || a.MergedIntoID != nil {
+		return
+	}
+	terms := map[string]float64{}
+	for _, name := range a.Name {
+		addTerms(terms, WeightAnime, Tokenize(name)...)
+	}
+	for _, slug := range a.Keywords {
+		addTerms(terms, WeightAnime, slugTerms(slug)...)
+	}
+	ix.animeTerms[a.ID] = terms
+	for t, w := range terms {
+		if ix.animePostings[t] == nil {
+			ix.animePostings[t] = map[int64]float64{}
+		}
+		ix.animePostings[t][a.ID] = w
+		ix.vocab.add(t)
+	}
+}
+
+// Remove tira o documento do índice (exclusão, moderação, despublicação).
+func (ix *Index) Remove(kind DocKind, id int64) {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	ix.drop(docKey{kind, id})
+}
+
+// Len: Total de documentos indexados.
+func (ix *Index) Len() int {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+	return len(ix.docs)
+}
+
+func (ix *Index) put(d *doc) {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+
+	ix.drop(d.key)
+	ix.docs[d.key] = d
+	for t, w := range d.terms {
+		if ix.postings[t] == nil {
+			ix.postings[t] = map[docKey]float64{}
+		}
+		ix.postings[t][d.key] = w
+		ix.vocab.add(t)
+	}
+	if d.idAnime > 0 {
+		if ix.animeDocs[d.idAnime] == nil {
+			ix.animeDocs[d.idAnime] = map[docKey]bool{}
+		}
+		ix.animeDocs[d.idAnime][d.key] = true
+	}
+}
+
+// drop: Chamador segura o lock.
+func (ix *Index) drop(key docKey) {
+	old, ok := ix.docs[key]
+	if !ok {
+		return
+	}
+	for t := range old.terms {
+		delete(ix.postings[t], key)
+		if len(ix.postings[t]) == 0 {
+			delete(ix.postings, t)
+			if ix.animePostings[t] == nil {
+				ix.vocab.remove(t)
+			}
+		}
+	}
+	if old.idAnime > 0 {
+		delete(ix.animeDocs[old.idAnime], key)
+	}
+	delete(ix.docs, key)
+}
+
+func (ix *Index) dropAnimeTerms(idAnime int64) {
+	for t := range ix.animeTerms[idAnime] {
+		delete(ix.animePostings[t], idAnime)
+		if len(ix.animePostings[t]) == 0 {
+			delete(ix.animePostings, t)
+			if ix.postings[t] == nil {
+				ix.vocab.remove(t)
+			}
+		}
+	}
+	delete(ix.animeTerms, idAnime)
+}
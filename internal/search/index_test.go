@@ -0,0 +1,125 @@
+package search
+
+import (
+	"otamaker-api/internal/models"
+	"testing"
+	"time"
+)
+
+func TestTokenize(t *testing.T) {
+	cases := []struct {
+		input string
+		want  []string
+	}{
+		{"Coração Partido!", []string{"coracao", "partido"}},
+		{"naruto-uzumaki 2", []string{"naruto", "uzumaki", "2"}},
+		{"😭 chorando", []string{"chorando"}},
+		{"", nil},
+	}
+	for _, tc := range cases {
+		got := Tokenize(tc.input)
+		if len(got) != len(tc.want) {
+			t.Errorf("Tokenize(%q) = %v, want %v", tc.input, got, tc.want)
+			continue
+		}
+		for i := range tc.want {
+			if got[i] != tc.want[i] {
+				t.Errorf("Tokenize(%q) = %v, want %v", tc.input, got, tc.want)
+			}
+		}
+	}
+}
+
+// newTestIndex: Stickers e packs de Naruto/One Piece para as consultas.
+func newTestIndex() *Index {
+	naruto, onePiece := int64(10), int64(20)
+	ix := NewIndex()
+	ix.UpsertAnime(&models.Anime{ID: naruto, IsVisible: true, Name: map[string]string{"pt_br": "Naruto Shippuden"}})
+	ix.UpsertAnime(&models.Anime{ID: onePiece, IsVisible: true, Name: map[string]string{"pt_br": "One Piece"}})
+	ix.UpsertSticker(&models.Sticker{ID: 1, IDAnime: &naruto, IsVisible: true, Keywords: []string{"crying", "naruto_uzumaki"}, DownloadsCount: 10})
+	ix.UpsertSticker(&models.Sticker{ID: 2, IDAnime: &naruto, IsVisible: true, Keywords: []string{"happy"}})
+	ix.UpsertSticker(&models.Sticker{ID: 3, IDAnime: &onePiece, IsVisible: true, Keywords: []string{"monkey_d_luffy", "crying"}, ContentRating: models.RatingAdult})
+	ix.UpsertSticker(&models.Sticker{ID: 4, IsVisible: false, Keywords: []string{"crying"}})
+	ix.UpsertPack(&models.Pack{ID: 50, IDAnime: onePiece, Status: models.PackPublished, Name: "Luffy memes", Keywords: []string{"funny"}})
+	ix.UpsertPack(&models.Pack{ID: 51, IDAnime: naruto, Status: models.PackDraft, Name: "Rascunho"})
+	return ix
+}
+
+func TestIndexVisibility(t *testing.T) {
+	ix := newTestIndex()
+	if ix.Len() != 4 {
+		t.Fatalf("Len = %d, want 4 (oculto e rascunho fora)", ix.Len())
+	}
+	ix.UpsertSticker(&models.Sticker{ID: 2, IsVisible: true, IsModerated: true})
+	ix.UpsertPack(&models.Pack{ID: 50, Status: models.PackPublished, IsDeleted: true})
+	if ix.Len() != 2 {
+		t.Errorf("Len = %d, want 2 após moderar/deletar", ix.Len())
+	}
+	if got := ix.Search("happy", Options{}); got.Total != 0 {
+		t.Errorf("sticker moderado ainda encontrado: %+v", got)
+	}
+}
+
+func TestIndexSearch(t *testing.T) {
+	resolver := models.NewKeywordResolver([]models.Keyword{
+		{ID: 1, Slug: "crying", Name: map[string]string{"pt_br": "Chorando"}},
+		{ID: 2, Slug: "monkey_d_luffy", Aliases: []string{"luffy"}},
+	})
+	cases := []struct {
+		name      string
+		query     string
+		opts      Options
+		wantHits  []int64
+		wantSlugs []string
+	}{
+		{"keyword exata", "crying", Options{}, []int64{1}, nil},
+		{"teto adulto", "crying", Options{MaxRating: models.RatingAdult}, []int64{1, 3}, nil},
+		{"prefixo", "cry", Options{}, []int64{1}, nil},
+		{"erro de digitação", "cryinh", Options{}, []int64{1}, nil},
+		{"todos os termos", "crying happy", Options{}, nil, nil},
+		{"nome do anime", "shippuden", Options{}, []int64{1, 2}, nil},
+		{"alias traduzido", "chorando", Options{Resolver: resolver}, []int64{1}, []string{"crying"}},
+		{"alias e nome do pack", "luffy memes", Options{Resolver: resolver, Kind: KindPack}, []int64{50}, []string{"monkey_d_luffy"}},
+		{"filtro de tipo", "shippuden", Options{Kind: KindPack}, nil, nil},
+		{"paginação", "shippuden", Options{Limit: 1, Offset: 1}, []int64{2}, nil},
+	}
+	ix := newTestIndex()
+	for _, tc := range cases {
+		got := ix.Search(tc.query, tc.opts)
+		if len(got.Hits) != len(tc.wantHits) {
+			t.Errorf("%s: hits = %+v, want %v", tc.name, got.Hits, tc.wantHits)
+			continue
+		}
+		for i, id := range tc.wantHits {
+			if got.Hits[i].ID != id {
+				t.Errorf("%s: hits[%d] = %d, want %d", tc.name, i, got.Hits[i].ID, id)
+			}
+		}
+		if len(got.Keywords) != len(tc.wantSlugs) {
+			t.Errorf("%s: keywords = %v, want %v", tc.name, got.Keywords, tc.wantSlugs)
+		}
+	}
+}
+
+func TestUpsertAnimeRename(t *testing.T) {
+	ix := newTestIndex()
+	ix.UpsertAnime(&models.Anime{ID: 10, IsVisible: true, Name: map[string]string{"pt_br": "Boruto"}})
+	if got := ix.Search("shippuden", Options{}); got.Total != 0 {
+		t.Errorf("nome antigo ainda encontrado: %+v", got.Hits)
+	}
+	if got := ix.Search("boruto", Options{}); got.Total != 2 {
+		t.Errorf("nome novo: total = %d, want 2", got.Total)
+	}
+}
+
+func TestRegisterSearch(t *testing.T) {
+	now := time.Date(2025, 5, 1, 14, 35, 0, 0, time.UTC)
+	keywords := map[string]*models.Keyword{"crying": {ID: 1, Slug: "crying", SearchCount: 4}}
+	touched, buckets := RegisterSearch(keywords, []string{"crying", "crying", "desconhecida"}, now)
+	if len(touched) != 1 || keywords["crying"].SearchCount != 5 {
+		t.Errorf("touched = %d, SearchCount = %d", len(touched), keywords["crying"].SearchCount)
+	}
+	if len(buckets) != 1 || buckets[0].Count != 1 || !buckets[0].Hour.Equal(now.Truncate(time.Hour)) {
+		t.Errorf("buckets = %+v", buckets)
+	}
+}
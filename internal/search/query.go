@@ -0,0 +1,362 @@
+package search
+
+import (
+	"math"
+	"otamaker-api/internal/models"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// ==========================================================
+// 1. VOCABULÁRIO (Prefixo e Tolerância a Erros)
+// ==========================================================
+
+// vocabulary: Todos os termos conhecidos, ordenados sob demanda para busca por prefixo.
+type vocabulary struct {
+	mu     sync.Mutex
+	set    map[string]bool
+	sorted []string
+	dirty  bool
+}
+
+func newVocabulary() *vocabulary {
+	return &vocabulary{set: map[string]bool{}}
+}
+
+func (v *vocabulary) add(t string) {
+	v.mu.Lock()
+	if !v.set[t] {
+		v.set[t] = true
+		v.dirty = true
+	}
+	v.mu.Unlock()
+}
+
+func (v *vocabulary) remove(t string) {
+	v.mu.Lock()
+	if v.set[t] {
+		delete(v.set, t)
+		v.dirty = true
+	}
+	v.mu.Unlock()
+}
+
+func (v *vocabulary) snapshot() []string {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.dirty {
+		v.sorted = make([]string, 0, len(v.set))
+		for t := range v.set {
+			v.sorted = append(v.sorted, t)
+		}
+		sort.Strings(v.sorted)
+		v.dirty = false
+	}
+	return v.sorted
+}
+
+// Fatores de correspondência aplicados ao peso do campo.
+const (
+	FactorExact  = 1.0
+	FactorPrefix = 0.8
+	FactorTypo   = 0.6
+
+	MinPrefixLen = 2
+	// PopularityWeight: Quanto a popularidade (downloads/likes) influencia o texto.
+	PopularityWeight = 0.15
+
+	DefaultLimit = 20
+	MaxLimit     = 50
+)
+
+// maxEdits: Erros tolerados pelo tamanho do termo (curto demais = só exato).
+func maxEdits(term string) int {
+	n := len([]rune(term))
+	switch {
+	case n >= 8:
+		return 2
+	case n >= 4:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// expand: Termos do vocabulário que casam com o termo digitado, com o fator de cada um.
+func expand(q string, vocab []string) map[string]float64 {
+	out := map[string]float64{q: FactorExact}
+	if !isWord(q) {
+		return out // Emojis: somente exato.
+	}
+
+	if len([]rune(q)) >= MinPrefixLen {
+		i := sort.SearchStrings(vocab, q)
+		for ; i < len(vocab) && strings.HasPrefix(vocab[i], q); i++ {
+			if vocab[i] != q {
+				out[vocab[i]] = FactorPrefix
+			}
+		}
+	}
+
+	if k := maxEdits(q); k > 0 {
+		qr := []rune(q)
+		for _, t := range vocab {
+			if _, ok := out[t]; ok {
+				continue
+			}
+			tr := []rune(t)
+			if abs(len(tr)-len(qr)) > k {
+				continue
+			}
+			if levenshtein(qr, tr, k) <= k {
+				out[t] = FactorTypo
+			}
+		}
+	}
+	return out
+}
+
+// levenshtein com corte: devolve limit+1 assim que a distância passa do limite.
+func levenshtein(a, b []rune, limit int) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		rowMin := curr[0]
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			rowMin = min(rowMin, curr[j])
+		}
+		if rowMin > limit {
+			return limit + 1
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+func isWord(t string) bool {
+	for _, r := range t {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// ==========================================================
+// 2. CONSULTA E RANKING
+// ==========================================================
+
+// Options: Filtros da busca.
+type Options struct {
+	Kind      DocKind                 // Vazio = Stickers e Packs.
+	MaxRating models.ContentRating    // Teto do usuário (ViewerMaxRating). Vazio = Safe.
+	Resolver  *models.KeywordResolver // Opcional: converte "chorando" -> "crying" antes de buscar.
+	Limit     int
+	Offset    int
+}
+
+// Hit: Documento encontrado. O Service carrega as entidades e mapeia (BuildPackResponses, StickersForViewer).
+type Hit struct {
+	Kind  DocKind `json:"kind"`
+	ID    int64   `json:"id"`
+	Score float64 `json:"score"`
+}
+
+// Result: Página de resultados + keywords reconhecidas na consulta (para 'Keyword.SearchCount').
+type Result struct {
+	Hits     []Hit    `json:"hits"`
+	Total    int      `json:"total"`
+	Keywords []string `json:"keywords"`
+}
+
+// queryTerms: Grupos de termos da consulta. Cada grupo casa se QUALQUER alternativa casar:
+// o token digitado e, quando ele é alias, também o slug canônico ("luffy" busca "luffy" e "monkey_d_luffy").
+// phrase: slug da frase inteira quando ela é um alias ("ninja loiro" -> "naruto_uzumaki"), buscado como alternativa aos grupos.
+func queryTerms(query string, resolver *models.KeywordResolver) (groups [][]string, phrase string, slugs []string) {
+	if resolver != nil && len(strings.Fields(query)) > 1 {
+		if k, ok := resolver.Lookup(query); ok {
+			phrase = k.Slug
+			slugs = append(slugs, k.Slug)
+		}
+	}
+
+	for _, raw := range strings.Fields(query) {
+		if !isWord(raw) {
+			for _, e := range models.SplitEmojis(raw) {
+				groups = append(groups, []string{models.NormalizeEmoji(e)})
+			}
+			continue
+		}
+		for _, t := range Tokenize(raw) {
+			group := []string{t}
+			if resolver != nil {
+				if k, ok := resolver.Lookup(t); ok {
+					slugs = append(slugs, k.Slug)
+					if k.Slug != t {
+						group = append(group, k.Slug)
+					}
+				}
+			}
+			groups = append(groups, group)
+		}
+	}
+	return groups, phrase, slugs
+}
+
+// Search: Todos os grupos de termos precisam casar (E). A relevância é a soma do melhor peso de cada grupo,
+// multiplicada pela popularidade: 1 + PopularityWeight * log10(1 + downloads + 2*likes).
+func (ix *Index) Search(query string, opts Options) Result {
+	groups, phrase, slugs := queryTerms(query, opts.Resolver)
+	result := Result{Hits: []Hit{}, Keywords: slugs}
+	if len(groups) == 0 && phrase == "" {
+		return result
+	}
+
+	vocab := ix.vocab.snapshot()
+
+	ix.mu.RLock()
+	relevance := ix.match(groups, vocab)
+	if phrase != "" {
+		for key, s := range ix.match([][]string{{phrase}}, vocab) {
+			relevance[key] = math.Max(relevance[key], s)
+		}
+	}
+
+	hits := ix.rank(relevance, opts)
+	ix.mu.RUnlock()
+
+	return paginate(result, hits, opts)
+}
+
+// match: Documentos que casam com todos os grupos e a soma dos melhores pesos de cada um.
+// Chamador segura o RLock.
+func (ix *Index) match(groups [][]string, vocab []string) map[docKey]float64 {
+	var relevance map[docKey]float64
+	for _, group := range groups {
+		scores := map[docKey]float64{}
+		for _, q := range group {
+			for t, factor := range expand(q, vocab) {
+				for key, w := range ix.postings[t] {
+					scores[key] = math.Max(scores[key], w*factor)
+				}
+				for idAnime, w := range ix.animePostings[t] {
+					for key := range ix.animeDocs[idAnime] {
+						scores[key] = math.Max(scores[key], w*factor)
+					}
+				}
+			}
+		}
+
+		if relevance == nil {
+			relevance = scores
+			continue
+		}
+		for key, total := range relevance {
+			s, ok := scores[key]
+			if !ok {
+				delete(relevance, key)
+				continue
+			}
+			relevance[key] = total + s
+		}
+	}
+	if relevance == nil {
+		relevance = map[docKey]float64{}
+	}
+	return relevance
+}
+
+// rank aplica filtros (tipo e classificação) e mescla relevância com popularidade.
+// Chamador segura o RLock.
+func (ix *Index) rank(relevance map[docKey]float64, opts Options) []Hit {
+	ceiling := opts.MaxRating.Normalize()
+	hits := make([]Hit, 0, len(relevance))
+	for key, rel := range relevance {
+		d := ix.docs[key]
+		if d == nil || (opts.Kind != "" && key.Kind != opts.Kind) || !ceiling.Allows(d.rating) {
+			continue
+		}
+		popularity := math.Log10(1 + float64(d.downloads) + 2*float64(d.likes))
+		hits = append(hits, Hit{Kind: key.Kind, ID: key.ID, Score: rel * (1 + PopularityWeight*popularity)})
+	}
+
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		if hits[i].Kind != hits[j].Kind {
+			return hits[i].Kind < hits[j].Kind
+		}
+		return hits[i].ID > hits[j].ID
+	})
+	return hits
+}
+
+func paginate(result Result, hits []Hit, opts Options) Result {
+	result.Total = len(hits)
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+	if opts.Offset >= len(hits) {
+		return result
+	}
+	end := min(opts.Offset+limit, len(hits))
+	result.Hits = hits[opts.Offset:end]
+	return result
+}
+
+// RegisterSearch incrementa 'SearchCount' das keywords reconhecidas na busca
+// e devolve os baldes da hora corrente (+1 cada) para o cálculo de tendências (trending.go).
+// keywords: chave = slug. O Service persiste as keywords alteradas e faz upsert dos baldes.
+func RegisterSearch(keywords map[string]*models.Keyword, slugs []string, now time.Time) ([]*models.Keyword, []models.KeywordSearchBucket) {
+	var touched []*models.Keyword
+	var buckets []models.KeywordSearchBucket
+	hour := models.SearchHour(now)
+	seen := map[string]bool{}
+	for _, slug := range slugs {
+		k, ok := keywords[slug]
+		if !ok || seen[slug] {
+			continue
+		}
+		seen[slug] = true
+		k.SearchCount++
+		touched = append(touched, k)
+		buckets = append(buckets, models.KeywordSearchBucket{IDKeyword: k.ID, Hour: hour, Count: 1})
+	}
+	return touched, buckets
+}
+
+/*
+REGRAS DA BUSCA:
+1.  Escopo: Indexa keywords e emojis dos stickers, nome/descrição/keywords dos packs e o nome dos animes em todos os idiomas.
+2.  Visibilidade: Só entram stickers visíveis, não deletados e não moderados; e packs publicados e não banidos.
+3.  Incremental: Criar, editar ou moderar chama UpsertSticker/UpsertPack/UpsertAnime (ou Remove). Não existe reindexação total no fluxo normal.
+4.  Tolerância: Prefixo a partir de 2 letras; 1 erro de digitação a partir de 4 letras e 2 erros a partir de 8. Emojis só casam exatos.
+5.  Ranking: Relevância textual (peso do campo x tipo de correspondência) mesclada com DownloadsCount/LikesCount em escala log.
+6.  Classificação: Resultados acima do teto do usuário (ContentRating) são descartados. Spoilers seguem StickersForViewer/PacksForViewer.
+7.  Tendência: Cada busca incrementa 'Keyword.SearchCount' e o balde horário das keywords reconhecidas (RegisterSearch).
+8.  Aliases: O termo digitado continua sendo buscado junto com o slug canônico; vale o que casar melhor ("Luffy memes" acha o pack pelo nome).
+*/
@@ -0,0 +1,15 @@
+-- Ciclo de vida dos packs (ver internal/models/pack_lifecycle.go).
+-- Packs criados antes da coluna 'status' ficaram com valor vazio.
+-- O antigo 'is_visible' define o estado: visível = publicado, oculto = rascunho.
+
+UPDATE packs
+SET status = CASE WHEN is_visible THEN 'published' ELSE 'draft' END
+WHERE status IS NULL OR status = '';
+
+-- Pack publicado sem data de publicação herda a data de criação.
+UPDATE packs
+SET published_at = created_at
+WHERE status = 'published' AND published_at IS NULL;
+
+ALTER TABLE packs ALTER COLUMN status SET DEFAULT 'draft';
+ALTER TABLE packs ALTER COLUMN status SET NOT NULL;
@@ -0,0 +1,14 @@
+-- Nome de exibição das keywords (ver internal/models/keyword.go).
+-- A coluna 'name' guardava texto simples e passou a guardar o JSON I18N
+-- (mesmo padrão de animes.name). Nomes antigos viram o idioma padrão (pt_br).
+-- Valores que já são um objeto JSON não são tocados (a migração pode rodar de novo).
+
+UPDATE keywords
+SET name = json_build_object('pt_br', name)::text
+WHERE name IS NOT NULL
+  AND name <> ''
+  AND left(ltrim(name), 1) <> '{';
+
+UPDATE keywords
+SET name = '{}'
+WHERE name IS NULL OR name = '';